@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/jackmorganxyz/projectsCLI/internal/cli"
 	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -13,7 +18,20 @@ import (
 var version = "dev"
 
 func main() {
-	if err := newRootCmd().Execute(); err != nil {
+	// Ctrl-C cancels the context so in-flight git and child processes are
+	// stopped; a second Ctrl-C falls back to the default (exit immediately).
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := newRootCmd().ExecuteContext(ctx); err != nil {
+		// Commands that wrap a child process pass its exit code through.
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -27,6 +45,8 @@ func newRootCmd() *cobra.Command {
 
 	var jsonOutput bool
 	var folderFilter string
+	var workspace string
+	var gitTimeout time.Duration
 	configPath := defaultConfigPath
 
 	rootCmd := &cobra.Command{
@@ -57,6 +77,12 @@ func newRootCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("load config %q: %w", configPath, err)
 			}
+			if workspace == "" {
+				workspace = cfg.Workspace
+			}
+			if cfg, err = cfg.ForWorkspace(workspace); err != nil {
+				return err
+			}
 
 			runtime := cli.RuntimeContext{
 				Config:     cfg,
@@ -64,7 +90,14 @@ func newRootCmd() *cobra.Command {
 				JSON:       jsonOutput,
 				Folder:     folderFilter,
 			}
-			cmd.SetContext(cli.WithRuntimeContext(cmd.Context(), runtime))
+			ctx := cmd.Context()
+			if !tui.IsInteractive() {
+				// Fail fast instead of hanging on a credential prompt.
+				ctx = git.WithNoPrompt(ctx)
+			}
+			git.CommandTimeout = gitTimeout
+
+			cmd.SetContext(cli.WithRuntimeContext(ctx, runtime))
 			tui.SetJSON(jsonOutput)
 
 			return nil
@@ -73,7 +106,9 @@ func newRootCmd() *cobra.Command {
 
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output JSON (auto-enabled when piped)")
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", defaultConfigPath, "path to config file")
+	rootCmd.PersistentFlags().StringVar(&workspace, "workspace", "", "use this workspace instead of the active one")
 	rootCmd.PersistentFlags().StringVar(&folderFilter, "folder", "", "target a specific folder (for multi-account setups)")
+	rootCmd.PersistentFlags().DurationVar(&gitTimeout, "git-timeout", git.CommandTimeout, "timeout for each git/gh subprocess other than push, fetch and clone (0 for none)")
 
 	rootCmd.AddCommand(
 		cli.NewCreateCmd(),
@@ -85,9 +120,23 @@ func newRootCmd() *cobra.Command {
 		cli.NewOpenCmd(),
 		cli.NewStatusCmd(),
 		cli.NewPushCmd(),
+		cli.NewSyncCmd(),
+		cli.NewScanCmd(),
+		cli.NewHooksCmd(),
+		cli.NewRemoteCmd(),
+		cli.NewIssuesCmd(),
+		cli.NewBranchCmd(),
+		cli.NewPRCmd(),
+		cli.NewAdoptCmd(),
+		cli.NewCloneCmd(),
 		cli.NewUpdateCmd(),
 		cli.NewFolderCmd(),
+		cli.NewWorkspaceCmd(),
 		cli.NewMoveCmd(),
+		cli.NewExecCmd(),
+		cli.NewForeachCmd(),
+		cli.NewRunCmd(),
+		cli.NewVaultCmd(),
 		cli.NewUpgradeCmd(version),
 	)
 
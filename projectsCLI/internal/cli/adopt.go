@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// Ways adopt can bring a directory under ProjectsDir.
+const (
+	adoptMoved   = "moved"
+	adoptLinked  = "linked"
+	adoptInPlace = "in_place"
+)
+
+type adoptResult struct {
+	Status    string   `json:"status"`
+	Slug      string   `json:"slug"`
+	Dir       string   `json:"dir"`  // where projects finds it
+	Path      string   `json:"path"` // where the files are
+	Mode      string   `json:"mode"`
+	Folder    string   `json:"folder,omitempty"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags,omitempty"`
+	GitRemote string   `json:"git_remote,omitempty"`
+	// Added lists the scaffold files and directories that were created.
+	Added []string `json:"added"`
+	// KeptProjectMD is set when the directory already had a PROJECT.md.
+	KeptProjectMD bool `json:"kept_project_md,omitempty"`
+}
+
+// NewAdoptCmd turns an existing directory or repo into a project.
+func NewAdoptCmd() *cobra.Command {
+	var (
+		slug       string
+		move, link bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "adopt <path>",
+		Short: "Turn an existing directory or repo into a project",
+		Long: `Make an existing directory a project without touching what's already there.
+
+PROJECT.md is written with the title and description from the README, tags
+for the main languages of its source files and git_remote from origin; a
+PROJECT.md that already exists is kept, apart from its slug when --slug
+gives another. Missing scaffold directories and
+files (docs/, tasks/TODO.md, private/, ...) are added, and private/ is
+appended to an existing .gitignore that doesn't ignore it.
+
+--move moves the directory into the projects directory (or --folder);
+--link leaves it where it is and symlinks it in. A directory already in the
+right place needs neither. The slug defaults to the directory name.`,
+		Example: "  projects adopt ~/src/my-app --link\n  projects adopt ~/src/old-tool --move --slug tool",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			ctx := cmd.Context()
+
+			src, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+			info, err := os.Stat(src)
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", src)
+			}
+
+			existing, err := project.LoadProject(src)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("read existing PROJECT.md: %w", err)
+			}
+			if slug == "" {
+				if existing != nil {
+					slug = existing.Meta.Slug
+				} else {
+					slug = Slugify(filepath.Base(src))
+				}
+			}
+			if err := ValidateSlug(slug); err != nil {
+				return err
+			}
+
+			projectsDir := runtime.Config.ProjectsDir
+			if runtime.Folder != "" {
+				if runtime.Config.FolderByName(runtime.Folder) == nil {
+					return fmt.Errorf("folder %q not configured; run 'projects folder add %s --account <gh-user>' first", runtime.Folder, runtime.Folder)
+				}
+				projectsDir = filepath.Join(runtime.Config.ProjectsDir, runtime.Folder)
+			}
+			dest := filepath.Join(projectsDir, slug)
+
+			res := adoptResult{Status: "adopted", Slug: slug, Dir: dest, Path: src, Folder: runtime.Folder, Added: []string{}}
+			switch {
+			case src == dest:
+				res.Mode = adoptInPlace
+			case move || link:
+				if _, err := os.Lstat(dest); err == nil {
+					return fmt.Errorf("destination already exists: %s", dest)
+				}
+			default:
+				return fmt.Errorf("%s is outside %s; pass --move to move it there or --link to symlink it in", src, projectsDir)
+			}
+
+			// Write the project files before moving anything, so a failure
+			// leaves the directory where it was.
+			var meta project.ProjectMeta
+			if existing != nil {
+				if err := setProjectSlug(src, existing, slug); err != nil {
+					return err
+				}
+				meta = existing.Meta
+				res.KeptProjectMD = true
+			} else {
+				meta = project.Infer(src, slug)
+				meta.Tags = folderTags(runtime.Config.FolderByName(runtime.Folder), meta.Tags)
+				g := runtime.GitBackend()
+				if g.IsRepo(ctx, src) {
+					if origin, err := g.RemoteURL(ctx, src); err == nil && origin != "" {
+						meta.GitRemote, _ = forge.WebURL(origin)
+					}
+				}
+				body := fmt.Sprintf("# %s\n\n%s\n", meta.Title, meta.Description)
+				if err := project.WriteProjectFile(src, meta, body); err != nil {
+					return fmt.Errorf("write PROJECT.md: %w", err)
+				}
+				res.Added = append(res.Added, "PROJECT.md")
+			}
+			added, err := project.CompleteScaffold(src, meta)
+			res.Added = append(res.Added, added...)
+			if err != nil {
+				return err
+			}
+			res.Title, res.Tags, res.GitRemote = meta.Title, meta.Tags, meta.GitRemote
+
+			if res.Mode == "" {
+				if err := os.MkdirAll(projectsDir, 0755); err != nil {
+					return fmt.Errorf("create destination directory: %w", err)
+				}
+				if move {
+					if err := os.Rename(src, dest); err != nil {
+						return fmt.Errorf("move project: %w", err)
+					}
+					res.Mode = adoptMoved
+					res.Path = dest
+				} else {
+					if err := os.Symlink(src, dest); err != nil {
+						return fmt.Errorf("link project: %w", err)
+					}
+					res.Mode = adoptLinked
+				}
+			}
+
+			g := runtime.GitBackend()
+			if g.IsRepo(ctx, dest) {
+				if err := applyFolderIdentity(ctx, g, dest, runtime.Config.FolderByName(runtime.Folder), nil); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("apply folder git identity: %v", err)))
+				}
+			}
+
+			// Regenerate registry.
+			_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), res)
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Adopted %s — %s", tui.Slug(slug), tui.RandomCreateCheer())))
+			fmt.Fprintln(w, tui.FormatField("Directory", tui.Path(dest)))
+			if res.Mode == adoptLinked {
+				fmt.Fprintln(w, tui.FormatField("Linked to", tui.Path(src)))
+			}
+			fmt.Fprintln(w, tui.FormatField("Title", meta.Title))
+			if len(meta.Tags) > 0 {
+				fmt.Fprintln(w, tui.FormatField("Tags", tui.TagList(meta.Tags)))
+			}
+			if meta.GitRemote != "" {
+				fmt.Fprintln(w, tui.FormatField("Remote", tui.Path(meta.GitRemote)))
+			}
+			if len(res.Added) > 0 {
+				fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  Added %d scaffold file(s); nothing existing was overwritten.", len(res.Added))))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&slug, "slug", "", "project slug (default: the directory name)")
+	cmd.Flags().BoolVar(&move, "move", false, "move the directory into the projects directory")
+	cmd.Flags().BoolVar(&link, "link", false, "leave the directory in place and symlink it into the projects directory")
+	cmd.MarkFlagsMutuallyExclusive("move", "link")
+
+	return cmd
+}
+
+// setProjectSlug rewrites the existing PROJECT.md in dir when slug differs
+// from the one it names, so list and view find the project under its new
+// directory name.
+func setProjectSlug(dir string, p *project.Project, slug string) error {
+	if p.Meta.Slug == slug {
+		return nil
+	}
+	p.Meta.Slug = slug
+	if err := project.WriteProjectFile(dir, p.Meta, p.Body); err != nil {
+		return fmt.Errorf("update PROJECT.md slug: %w", err)
+	}
+	return nil
+}
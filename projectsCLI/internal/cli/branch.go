@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// NewBranchCmd starts a topic branch in a project.
+func NewBranchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branch <slug> <name>",
+		Short: "Start a topic branch for a piece of work",
+		Long: `Create a branch from the project's current branch and switch to it.
+
+The branch it started from is remembered in the repo's git config, and
+'projects pr' opens the pull request against it.`,
+		Example: "  projects branch my-app fix-login\n  projects pr my-app",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			ctx := cmd.Context()
+			g := runtime.GitBackend()
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			if !g.IsRepo(ctx, proj.Dir) {
+				return fmt.Errorf("%s is not a git repository yet — run 'projects push %s' first", proj.Meta.Slug, proj.Meta.Slug)
+			}
+			base, err := g.CurrentBranch(ctx, proj.Dir)
+			if err != nil {
+				return err
+			}
+			if base == "" {
+				return fmt.Errorf("%s has a detached HEAD; check out a branch first", proj.Meta.Slug)
+			}
+
+			name := args[1]
+			if err := g.CreateBranch(ctx, proj.Dir, name); err != nil {
+				return fmt.Errorf("create branch: %w", err)
+			}
+			if err := g.SetLocalConfig(ctx, proj.Dir, baseBranchKey(name), base); err != nil {
+				return fmt.Errorf("record base branch: %w", err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]string{
+					"status": "created",
+					"slug":   proj.Meta.Slug,
+					"branch": name,
+					"base":   base,
+				})
+			}
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Switched to new branch %s (from %s).", tui.Slug(name), base)))
+			fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  Open a pull request when it's ready with: projects pr %s", proj.Meta.Slug)))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// baseBranchKey is the git config key recording the branch name started from.
+func baseBranchKey(name string) string {
+	return "branch." + name + ".projectsBase"
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+type cloneResult struct {
+	Status string `json:"status"`
+	Slug   string `json:"slug"`
+	Dir    string `json:"dir"`
+	URL    string `json:"url"`
+	Folder string `json:"folder,omitempty"`
+	// ProjectMD is "existing" when the repo had a PROJECT.md, else "generated".
+	ProjectMD string `json:"project_md"`
+	// Added lists the scaffold files and directories created locally.
+	Added []string `json:"added"`
+}
+
+// NewCloneCmd clones a hosted repository into a new project.
+func NewCloneCmd() *cobra.Command {
+	var slug string
+
+	cmd := &cobra.Command{
+		Use:   "clone <url|owner/repo>",
+		Short: "Clone a repository into a new project",
+		Long: `Clone a repository into the projects directory, or into --folder.
+
+owner/repo is looked up on the folder's forge; any other argument is passed
+to git as the clone URL. The clone authenticates as the folder's account and
+gets the folder's git author, signing and SSH settings; over HTTPS on a
+forge other than github.com, git reads the folder's API token for the clone
+and later pushes.
+
+A PROJECT.md in the repo is used as is, except that its slug is updated
+when --slug gives another. Otherwise one is generated from the
+README and the repo's languages, like 'projects adopt'. Either way, missing
+scaffold directories and files are added locally; nothing is committed.`,
+		Example: "  projects clone octo/my-app\n  projects clone git@github.com:octo/my-app.git --folder work",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			ctx := cmd.Context()
+
+			var folder *config.Folder
+			projectsDir := runtime.Config.ProjectsDir
+			if runtime.Folder != "" {
+				if folder = runtime.Config.FolderByName(runtime.Folder); folder == nil {
+					return fmt.Errorf("folder %q not configured; run 'projects folder add %s --account <gh-user>' first", runtime.Folder, runtime.Folder)
+				}
+				projectsDir = filepath.Join(runtime.Config.ProjectsDir, runtime.Folder)
+			}
+
+			url, name, err := cloneURL(ctx, runtime, folder, args[0])
+			if err != nil {
+				return err
+			}
+			slugGiven := slug != ""
+			if !slugGiven {
+				slug = Slugify(name)
+			}
+			if err := ValidateSlug(slug); err != nil {
+				return err
+			}
+			dir := filepath.Join(projectsDir, slug)
+			if _, err := os.Lstat(dir); err == nil {
+				return fmt.Errorf("project directory already exists: %s", dir)
+			}
+			if err := os.MkdirAll(projectsDir, 0755); err != nil {
+				return fmt.Errorf("create destination directory: %w", err)
+			}
+
+			cloneCtx := ctx
+			var settings []string
+			for _, s := range folderGitSettings(folder) {
+				settings = append(settings, s.Key+"="+s.Value)
+			}
+			if usesGHCLI(folder) {
+				scoped, restore, err := withGHAccount(ctx, runtime.ForgeBackend(), folderAccount(runtime.Config, folder))
+				defer restore()
+				if err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(err.Error()))
+				}
+				cloneCtx = scoped
+			} else if kind, err := folderForge(folder); err == nil {
+				// Other forges authenticate HTTPS with the folder's token,
+				// for the clone and for later pushes.
+				for _, kv := range tokenCredentials(url, folderAccount(runtime.Config, folder), forgeTokenEnv(folder, kind)) {
+					settings = append(settings, kv[0]+"="+kv[1])
+				}
+			}
+			g := runtime.GitBackend()
+			if err := g.Clone(cloneCtx, url, dir, settings); err != nil {
+				return fmt.Errorf("clone %s: %w", url, err)
+			}
+
+			res := cloneResult{Status: "cloned", URL: url, Folder: runtime.Folder}
+			existing, err := project.LoadProject(dir)
+			switch {
+			case err == nil:
+				res.ProjectMD = "existing"
+				// The repo names itself; use that unless told otherwise.
+				if want := existing.Meta.Slug; want != slug && !slugGiven && ValidateSlug(want) == nil {
+					target := filepath.Join(projectsDir, want)
+					if _, err := os.Lstat(target); err == nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("PROJECT.md names the project %s, but %s already exists; keeping %s", want, target, slug)))
+					} else if err := os.Rename(dir, target); err != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("PROJECT.md names the project %s, but moving the clone there failed (%v); keeping %s", want, err, slug)))
+					} else {
+						slug, dir = want, target
+					}
+				}
+				if err := setProjectSlug(dir, existing, slug); err != nil {
+					return err
+				}
+				res.Added, err = project.CompleteScaffold(dir, existing.Meta)
+			case os.IsNotExist(err):
+				res.ProjectMD = "generated"
+				meta := project.Infer(dir, slug)
+				meta.Tags = folderTags(folder, meta.Tags)
+				if web, err := forge.WebURL(url); err == nil {
+					meta.GitRemote = web
+				} else {
+					meta.GitRemote = url
+				}
+				body := fmt.Sprintf("# %s\n\n%s\n", meta.Title, meta.Description)
+				if err := project.WriteProjectFile(dir, meta, body); err != nil {
+					return fmt.Errorf("write PROJECT.md: %w", err)
+				}
+				res.Added, err = project.CompleteScaffold(dir, meta)
+				res.Added = append([]string{"PROJECT.md"}, res.Added...)
+			default:
+				return fmt.Errorf("read PROJECT.md: %w", err)
+			}
+			if err != nil {
+				return err
+			}
+			res.Slug, res.Dir = slug, dir
+
+			if runtime.Config.InstallHooks {
+				installProjectHooks(ctx, g, dir, slug, cmd.ErrOrStderr())
+			}
+
+			// Regenerate registry.
+			_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), res)
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Cloned %s — %s", tui.Slug(slug), tui.RandomCreateCheer())))
+			fmt.Fprintln(w, tui.FormatField("Directory", tui.Path(dir)))
+			fmt.Fprintln(w, tui.FormatField("Remote", tui.Path(url)))
+			if runtime.Folder != "" {
+				fmt.Fprintln(w, tui.FormatField("Folder", tui.Slug(runtime.Folder)))
+			}
+			if res.ProjectMD == "generated" {
+				fmt.Fprintln(w, tui.Muted("  PROJECT.md was generated; commit it with 'projects push "+slug+"' to share it."))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&slug, "slug", "", "project slug (default: the repo's PROJECT.md slug or its name)")
+
+	return cmd
+}
+
+// cloneURL resolves the clone argument to a URL and the repo's name. An
+// owner/repo shorthand is looked up on folder's forge; the clone uses SSH
+// when the folder has an SSH command, as push does.
+func cloneURL(ctx context.Context, runtime RuntimeContext, folder *config.Folder, arg string) (url, name string, err error) {
+	if !isRepoShorthand(arg) {
+		if _, _, name, err := forge.ParseRepoURL(arg); err == nil {
+			return arg, name, nil
+		}
+		return arg, strings.TrimSuffix(filepath.Base(strings.TrimRight(arg, "/")), ".git"), nil
+	}
+
+	arg = strings.Trim(arg, "/")
+	i := strings.LastIndex(arg, "/")
+	owner, name := arg[:i], arg[i+1:]
+	p, err := providerFor(ctx, runtime, folder)
+	if err != nil {
+		return "", "", err
+	}
+	repo, err := p.GetRepo(ctx, owner, name)
+	if err != nil {
+		return "", "", fmt.Errorf("get %s from %s: %w", arg, p.Kind(), err)
+	}
+	if folder != nil && folder.SSHCommand != "" && repo.SSHURL != "" {
+		return repo.SSHURL, repo.Name, nil
+	}
+	return repo.CloneURL, repo.Name, nil
+}
+
+// isRepoShorthand reports whether arg is owner/repo (or group/sub/repo)
+// rather than a URL or a local path.
+func isRepoShorthand(arg string) bool {
+	if strings.Contains(arg, ":") || strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "~") {
+		return false
+	}
+	if !strings.Contains(strings.Trim(arg, "/"), "/") {
+		return false
+	}
+	_, err := os.Stat(arg)
+	return err != nil
+}
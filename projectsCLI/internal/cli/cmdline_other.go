@@ -0,0 +1,8 @@
+//go:build !windows
+
+package cli
+
+import "os/exec"
+
+// setCmdLine is only needed for cmd.exe on Windows.
+func setCmdLine(*exec.Cmd, string) {}
@@ -0,0 +1,12 @@
+package cli
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setCmdLine passes line to the process verbatim, as cmd.exe doesn't follow
+// the quoting rules exec uses to join the arguments.
+func setCmdLine(c *exec.Cmd, line string) {
+	c.SysProcAttr = &syscall.SysProcAttr{CmdLine: line}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
 )
 
 type runtimeContextKey struct{}
@@ -14,6 +15,26 @@ type RuntimeContext struct {
 	ConfigPath string
 	JSON       bool
 	Folder     string // optional folder filter for multi-account setups
+
+	// Git and Forge override the git/gh backends; nil means the real binaries.
+	Git   git.Git
+	Forge git.Forge
+}
+
+// GitBackend returns the git implementation commands should use.
+func (r RuntimeContext) GitBackend() git.Git {
+	if r.Git != nil {
+		return r.Git
+	}
+	return git.Exec{}
+}
+
+// ForgeBackend returns the forge implementation commands should use.
+func (r RuntimeContext) ForgeBackend() git.Forge {
+	if r.Forge != nil {
+		return r.Forge
+	}
+	return git.GHCLI{}
 }
 
 // WithRuntimeContext attaches runtime state to a context.
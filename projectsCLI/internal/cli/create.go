@@ -9,7 +9,6 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jackmorganxyz/projectsCLI/internal/agent"
-	"github.com/jackmorganxyz/projectsCLI/internal/git"
 	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
@@ -22,6 +21,7 @@ func NewCreateCmd() *cobra.Command {
 		description string
 		tags        []string
 		status      string
+		noHooks     bool
 	)
 
 	cmd := &cobra.Command{
@@ -29,13 +29,15 @@ func NewCreateCmd() *cobra.Command {
 		Short: "Create a new project",
 		Long: `Create a new project scaffold with directory structure and template files.
 
-If slug is omitted, it is generated from --title.`,
+If slug is omitted, it is generated from --title. When git is initialized,
+the managed git hooks are installed too (see 'projects hooks').`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
 			if !ok {
 				return fmt.Errorf("missing runtime context")
 			}
+			ctx := cmd.Context()
 
 			var slug string
 			if len(args) == 1 {
@@ -67,10 +69,12 @@ If slug is omitted, it is generated from --title.`,
 			// Determine the target directory.
 			projectsDir := runtime.Config.ProjectsDir
 			if runtime.Folder != "" {
-				if runtime.Config.FolderByName(runtime.Folder) == nil {
+				folder := runtime.Config.FolderByName(runtime.Folder)
+				if folder == nil {
 					return fmt.Errorf("folder %q not configured; run 'projects folder add %s --account <gh-user>' first", runtime.Folder, runtime.Folder)
 				}
 				projectsDir = filepath.Join(runtime.Config.ProjectsDir, runtime.Folder)
+				meta.Tags = folderTags(folder, meta.Tags)
 			}
 
 			dir, err := project.Scaffold(projectsDir, meta)
@@ -80,16 +84,24 @@ If slug is omitted, it is generated from --title.`,
 
 			// Auto-init git if configured.
 			if runtime.Config.AutoGitInit {
-				if err := git.Init(dir); err != nil {
+				g := runtime.GitBackend()
+				if err := g.Init(ctx, dir); err != nil {
 					fmt.Fprintf(cmd.ErrOrStderr(), "warning: git init failed: %v\n", err)
 				} else {
-					_ = git.AddAll(dir)
-					_ = git.Commit(dir, "Initial project scaffold")
+					// Before the first commit, so it carries the folder's identity.
+					if err := applyFolderIdentity(ctx, g, dir, runtime.Config.FolderByName(runtime.Folder), nil); err != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("apply folder git identity: %v", err)))
+					}
+					_ = g.AddAll(ctx, dir)
+					_ = g.Commit(ctx, dir, "Initial project scaffold")
+					if runtime.Config.InstallHooks && !noHooks {
+						installProjectHooks(ctx, g, dir, slug, cmd.ErrOrStderr())
+					}
 				}
 			}
 
 			// Regenerate registry.
-			_ = project.WriteRegistry(runtime.Config.ProjectsDir)
+			_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
 
 			if tui.IsJSON() {
 				result := map[string]any{
@@ -132,6 +144,7 @@ If slug is omitted, it is generated from --title.`,
 	cmd.Flags().StringVar(&description, "description", "", "project description")
 	cmd.Flags().StringSliceVar(&tags, "tags", nil, "project tags (comma-separated)")
 	cmd.Flags().StringVar(&status, "status", "active", "project status")
+	cmd.Flags().BoolVar(&noHooks, "no-hooks", false, "don't install the managed git hooks")
 
 	return cmd
 }
@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
 	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
@@ -11,13 +15,30 @@ import (
 
 // NewDeleteCmd deletes a project.
 func NewDeleteCmd() *cobra.Command {
-	var force bool
+	var (
+		force    bool
+		external bool
+		remote   string
+	)
 
 	cmd := &cobra.Command{
 		Use:     "delete <slug>",
 		Aliases: []string{"rm"},
 		Short:   "Delete a project",
-		Long:    "Delete a project and its directory. Use --force to skip confirmation.",
+		Long: `Delete a project and its directory. Use --force to skip confirmation.
+
+The hosted repo is left alone unless --remote says otherwise: "archive"
+makes it read-only on the forge and "delete" removes it for good. Both ask
+for confirmation first; in an interactive session a project with a remote is
+offered archiving when --remote isn't given. If the forge call fails the
+local directory is kept.
+
+External projects (symlinked in, or under links in the config) are
+unregistered rather than deleted: the symlink or the links entry is removed
+and the directory it points to is left alone. Pass --external to delete
+that directory too. A project found under a links entry that holds other
+projects can only be deleted with --external.`,
+		Example: "  projects delete my-app\n  projects delete my-app --remote archive\n  projects delete my-app --remote delete --force\n  projects delete linked-app --external",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
@@ -25,11 +46,30 @@ func NewDeleteCmd() *cobra.Command {
 				return fmt.Errorf("missing runtime context")
 			}
 
+			switch remote {
+			case "", remoteKeep, remoteArchive, remoteDelete:
+			default:
+				return fmt.Errorf("--remote must be %q, %q or %q", remoteKeep, remoteArchive, remoteDelete)
+			}
+
 			slug := args[0]
 			proj, err := findProject(runtime.Config, slug, runtime.Folder)
 			if err != nil {
 				return err
 			}
+			ctx := cmd.Context()
+			// An external project is unregistered by removing its symlink or
+			// its own links entry; only --external touches the directory.
+			linkIndex := slices.IndexFunc(runtime.Config.Links, func(link string) bool {
+				return filepath.Clean(link) == filepath.Clean(proj.Dir)
+			})
+			isSymlink := false
+			if info, err := os.Lstat(proj.Dir); err == nil {
+				isSymlink = info.Mode()&os.ModeSymlink != 0
+			}
+			if proj.External && !external && !isSymlink && linkIndex < 0 {
+				return fmt.Errorf("project %q is inside a links entry with other projects; pass --external to delete %s", slug, proj.Dir)
+			}
 
 			if !force && tui.IsInteractive() {
 				confirmed, err := tui.RunConfirm(tui.RandomDeleteConfirm(slug))
@@ -44,27 +84,138 @@ func NewDeleteCmd() *cobra.Command {
 				return fmt.Errorf("use --force to delete without confirmation in non-interactive mode")
 			}
 
-			if err := os.RemoveAll(proj.Dir); err != nil {
-				return fmt.Errorf("remove project directory: %w", err)
+			var result *remoteResult
+			confirmed := force
+			if remote == "" && !force && tui.IsInteractive() && hasProjectRemote(ctx, runtime, proj) {
+				archive, err := tui.RunConfirm("Archive its remote repo too?")
+				if err != nil {
+					return err
+				}
+				if archive {
+					remote, confirmed = remoteArchive, true
+				}
+			}
+			if remote == remoteArchive || remote == remoteDelete {
+				result, err = retireRemote(ctx, runtime, proj, remote, confirmed)
+				if err != nil {
+					return err
+				}
+			}
+
+			status := "deleted"
+			target := project.RealDir(proj.Dir)
+			switch {
+			case !proj.External:
+				if err := os.RemoveAll(proj.Dir); err != nil {
+					return fmt.Errorf("remove project directory: %w", err)
+				}
+			default:
+				if isSymlink {
+					if err := os.Remove(proj.Dir); err != nil {
+						return fmt.Errorf("remove project link: %w", err)
+					}
+				}
+				if linkIndex >= 0 {
+					runtime.Config.Links = slices.Delete(slices.Clone(runtime.Config.Links), linkIndex, linkIndex+1)
+					if err := config.SaveToPath(runtime.Config, runtime.ConfigPath); err != nil {
+						return fmt.Errorf("save config: %w", err)
+					}
+				}
+				if external {
+					if err := os.RemoveAll(target); err != nil {
+						return fmt.Errorf("remove project directory: %w", err)
+					}
+				} else {
+					status = "unregistered"
+				}
 			}
 
 			// Regenerate registry.
-			_ = project.WriteRegistry(runtime.Config.ProjectsDir)
+			_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
 
 			if tui.IsJSON() {
-				return writeJSON(cmd.OutOrStdout(), map[string]string{
-					"status": "deleted",
+				out := map[string]any{
+					"status": status,
 					"slug":   slug,
-				})
+				}
+				if result != nil {
+					out["remote"] = result
+				}
+				return writeJSON(cmd.OutOrStdout(), out)
 			}
 
-			fmt.Fprintln(cmd.OutOrStdout(), tui.SuccessMessage(fmt.Sprintf("Deleted project %s", tui.Slug(slug))))
+			if status == "unregistered" {
+				fmt.Fprintln(cmd.OutOrStdout(), tui.SuccessMessage(fmt.Sprintf("Unregistered project %s; %s was left alone", tui.Slug(slug), tui.Path(target))))
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), tui.SuccessMessage(fmt.Sprintf("Deleted project %s", tui.Slug(slug))))
+			}
+			if result != nil {
+				fmt.Fprintln(cmd.OutOrStdout(), tui.SuccessMessage(fmt.Sprintf("Remote repo %s %s.", result.Repo, result.Action)))
+			}
 			fmt.Fprintln(cmd.OutOrStdout(), tui.WarningMessage(tui.RandomDeleteFarewell()))
 			return nil
 		},
 	}
 
-	cmd.Flags().BoolVar(&force, "force", false, "skip confirmation prompt")
+	cmd.Flags().BoolVar(&force, "force", false, "skip confirmation prompts")
+	cmd.Flags().BoolVar(&external, "external", false, "delete an external project's directory instead of just unregistering it")
+	cmd.Flags().StringVar(&remote, "remote", "", "what to do with the hosted repo: keep, archive or delete")
 
 	return cmd
 }
+
+// What delete does with a project's hosted repo.
+const (
+	remoteKeep    = "keep"
+	remoteArchive = "archive"
+	remoteDelete  = "delete"
+)
+
+// remoteResult reports what happened to a project's hosted repo.
+type remoteResult struct {
+	Repo   string `json:"repo"`
+	Action string `json:"action"` // archived, deleted, transferred or pending
+	To     string `json:"to,omitempty"`
+	Origin string `json:"origin,omitempty"`
+}
+
+func hasProjectRemote(ctx context.Context, runtime RuntimeContext, proj *project.Project) bool {
+	if url, err := runtime.GitBackend().RemoteURL(ctx, proj.Dir); err == nil && url != "" {
+		return true
+	}
+	return proj.Meta.GitRemote != ""
+}
+
+// retireRemote archives or deletes proj's hosted repo, asking first unless
+// the user already confirmed.
+func retireRemote(ctx context.Context, runtime RuntimeContext, proj *project.Project, action string, confirmed bool) (*remoteResult, error) {
+	p, owner, name, err := projectRemote(ctx, runtime, proj)
+	if err != nil {
+		return nil, err
+	}
+	repo := owner + "/" + name
+	if !confirmed && tui.IsInteractive() {
+		question := fmt.Sprintf("Archive %s on %s? It becomes read-only.", repo, p.Kind())
+		if action == remoteDelete {
+			question = fmt.Sprintf("Permanently delete %s on %s? This cannot be undone.", repo, p.Kind())
+		}
+		ok, err := tui.RunConfirm(question)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("cancelled; %s and the local project were left as they are", repo)
+		}
+	}
+
+	if action == remoteDelete {
+		if err := p.DeleteRepo(ctx, owner, name); err != nil {
+			return nil, fmt.Errorf("delete %s: %w", repo, err)
+		}
+		return &remoteResult{Repo: repo, Action: "deleted"}, nil
+	}
+	if err := p.ArchiveRepo(ctx, owner, name); err != nil {
+		return nil, fmt.Errorf("archive %s: %w", repo, err)
+	}
+	return &remoteResult{Repo: repo, Action: "archived"}, nil
+}
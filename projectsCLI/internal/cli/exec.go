@@ -0,0 +1,295 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ExitError carries a child process exit code back to main so it can be
+// propagated without printing an extra error line.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// execResult is the outcome of running a command inside one project.
+type execResult struct {
+	Slug       string `json:"slug"`
+	Folder     string `json:"folder,omitempty"`
+	Dir        string `json:"dir"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewExecCmd runs a command inside a single project directory.
+func NewExecCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <slug> -- <command> [args...]",
+		Short: "Run a command inside a project",
+		Long: `Run a command in the project directory with PROJECT_* variables
+(the same ones 'load --export' prints) added to its environment.
+
+The command's exit code is passed through.`,
+		Example: "  projects exec my-app -- git pull\n  projects exec my-app -- sh -c 'cd code && make test'",
+		Args:    cobra.MinimumNArgs(2),
+		// main reports errors itself and passes ExitError codes through silently.
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			if dash := cmd.ArgsLenAtDash(); dash != -1 && dash != 1 {
+				return fmt.Errorf("expected exactly one slug before --")
+			}
+
+			slug := args[0]
+			proj, err := findProject(runtime.Config, slug, runtime.Folder)
+			if err != nil {
+				return err
+			}
+
+			c := projectCommand(cmd.Context(), proj, args[1], args[2:]...)
+			c.Stdin = os.Stdin
+			c.Stdout = cmd.OutOrStdout()
+			c.Stderr = cmd.ErrOrStderr()
+			return exitCodeError(c.Run())
+		},
+	}
+
+	return cmd
+}
+
+// NewForeachCmd runs a command across many projects.
+func NewForeachCmd() *cobra.Command {
+	var (
+		where    []string
+		parallel int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "foreach [--where key=value] [--parallel N] -- <command> [args...]",
+		Short: "Run a command in every matching project",
+		Long: `Run a command in each project directory, with PROJECT_* variables injected.
+
+Output lines are prefixed with the project slug. A summary of exit codes is
+printed at the end (or emitted as JSON with --json, in which case command
+output goes to stderr).
+
+Filter with --where key=value or key!=value (keys: slug, status, tag, folder;
+values may be glob patterns). Repeat --where to combine filters.`,
+		Example: "  projects foreach -- git pull\n  projects foreach --where tag=go --parallel 4 -- make test",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			clauses, err := parseWhere(where)
+			if err != nil {
+				return err
+			}
+			if parallel < 1 {
+				return fmt.Errorf("--parallel must be at least 1")
+			}
+
+			projects, err := listAllProjects(runtime.Config, runtime.Folder)
+			if err != nil {
+				return err
+			}
+			projects = filterProjects(projects, clauses)
+
+			if len(projects) == 0 {
+				if tui.IsJSON() {
+					return writeJSON(cmd.OutOrStdout(), []execResult{})
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), tui.Muted("No matching projects."))
+				return nil
+			}
+
+			// Keep stdout clean for the JSON summary.
+			out := cmd.OutOrStdout()
+			if tui.IsJSON() {
+				out = cmd.ErrOrStderr()
+			}
+			var mu sync.Mutex
+
+			ctx := cmd.Context()
+			results := make([]execResult, len(projects))
+			ran := make([]bool, len(projects))
+			forEachProject(ctx, projects, parallel, func(ctx context.Context, i int, p *project.Project) {
+				results[i] = runInProject(ctx, p, args, out, &mu)
+				ran[i] = true
+			})
+
+			// Projects never started because of Ctrl-C are dropped from the summary.
+			if ctx.Err() != nil {
+				var started []execResult
+				for i, r := range results {
+					if ran[i] {
+						started = append(started, r)
+					}
+				}
+				results = started
+			}
+
+			failed := 0
+			for _, r := range results {
+				if r.ExitCode != 0 {
+					failed++
+				}
+			}
+
+			if tui.IsJSON() {
+				if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+					return err
+				}
+			} else {
+				headers := []string{"Slug", "Exit", "Duration"}
+				var rows [][]string
+				for _, r := range results {
+					rows = append(rows, []string{
+						r.Slug,
+						strconv.Itoa(r.ExitCode),
+						(time.Duration(r.DurationMS) * time.Millisecond).String(),
+					})
+				}
+				w := cmd.OutOrStdout()
+				fmt.Fprintln(w)
+				fmt.Fprintln(w, tui.Table(headers, rows))
+			}
+
+			if ctx.Err() != nil {
+				return fmt.Errorf("interrupted after %d of %d projects", len(results), len(projects))
+			}
+			if failed > 0 {
+				return fmt.Errorf("command failed in %d of %d projects", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&where, "where", nil, "filter projects (key=value or key!=value; keys: slug, status, tag, folder)")
+	cmd.Flags().IntVarP(&parallel, "parallel", "p", 1, "number of projects to run concurrently")
+
+	return cmd
+}
+
+// runInProject runs argv inside a project, writing slug-prefixed output to w.
+func runInProject(ctx context.Context, p *project.Project, argv []string, w io.Writer, mu *sync.Mutex) execResult {
+	prefix := "[" + p.Meta.Slug + "] "
+	if !tui.IsJSON() {
+		prefix = "[" + tui.Slug(p.Meta.Slug) + "] "
+	}
+	stdout := &prefixWriter{w: w, mu: mu, prefix: prefix}
+	stderr := &prefixWriter{w: w, mu: mu, prefix: prefix}
+
+	c := projectCommand(ctx, p, argv[0], argv[1:]...)
+	c.Stdout = stdout
+	c.Stderr = stderr
+
+	start := time.Now()
+	err := c.Run()
+	stdout.Flush()
+	stderr.Flush()
+
+	result := execResult{
+		Slug:       p.Meta.Slug,
+		Folder:     p.Folder,
+		Dir:        p.Dir,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		var exitErr *ExitError
+		if errors.As(exitCodeError(err), &exitErr) {
+			result.ExitCode = exitErr.Code
+		} else {
+			result.ExitCode = -1
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// projectCommand builds a command that runs in the project directory with the
+// PROJECT_* variables appended to the current environment. When ctx is
+// cancelled the command gets an interrupt, then a kill if it doesn't exit.
+func projectCommand(ctx context.Context, p *project.Project, name string, args ...string) *exec.Cmd {
+	c := exec.CommandContext(ctx, name, args...)
+	c.Cancel = func() error { return c.Process.Signal(os.Interrupt) }
+	c.WaitDelay = 10 * time.Second
+	c.Dir = p.Dir
+	c.Env = os.Environ()
+	for _, v := range projectEnv(p) {
+		c.Env = append(c.Env, v.Name+"="+v.Value)
+	}
+	return c
+}
+
+// exitCodeError converts an *exec.ExitError into an *ExitError so the exit code
+// survives to main. Other errors are returned unchanged.
+func exitCodeError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
+
+// prefixWriter writes complete lines to w, each prefixed, holding mu per line
+// so output from concurrent commands doesn't interleave mid-line.
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	pw.buf.Write(p)
+	for {
+		line, err := pw.buf.ReadBytes('\n')
+		if err != nil {
+			// Incomplete line: keep it for the next write.
+			pw.buf.Write(line)
+			break
+		}
+		pw.writeLine(line)
+	}
+	return len(p), nil
+}
+
+// Flush writes any trailing output that didn't end in a newline.
+func (pw *prefixWriter) Flush() {
+	if pw.buf.Len() == 0 {
+		return
+	}
+	line := append(pw.buf.Bytes(), '\n')
+	pw.buf.Reset()
+	pw.writeLine(line)
+}
+
+func (pw *prefixWriter) writeLine(line []byte) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	io.WriteString(pw.w, pw.prefix)
+	pw.w.Write(line)
+}
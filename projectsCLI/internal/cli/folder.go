@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/jackmorganxyz/projectsCLI/internal/config"
 	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -21,21 +23,35 @@ func NewFolderCmd() *cobra.Command {
 		Long: `Manage named folders that group projects by GitHub account.
 
 Each folder maps to a subdirectory under your projects directory and is
-associated with a specific GitHub account. When you push a project that
-lives in a folder, the CLI automatically switches gh auth to the right account.`,
+associated with a specific GitHub account. When you push or sync a project
+that lives in a folder, gh and git run as that account for the duration of
+the command (via its gh token), and your active gh login is left unchanged.
+
+A folder can instead live on GitLab or a Gitea/Forgejo server (--forge and
+--base-url); its repos are then created through that forge's API.
+
+Folders can be nested (work/clients/acme) and inherit the settings they
+don't set from their parents; 'folder show' prints the effective ones.`,
 	}
 
 	cmd.AddCommand(
 		newFolderAddCmd(),
 		newFolderListCmd(),
 		newFolderRemoveCmd(),
+		newFolderApplyCmd(),
+		newFolderShowCmd(),
+		newFolderRenameCmd(),
+		newFolderMoveCmd(),
 	)
 
 	return cmd
 }
 
 func newFolderAddCmd() *cobra.Command {
-	var account string
+	var (
+		account  string
+		identity config.Folder
+	)
 
 	cmd := &cobra.Command{
 		Use:   "add <name>",
@@ -46,30 +62,74 @@ The folder name is used as a subdirectory under your projects directory.
 Projects created with --folder <name> will live in this directory and
 push using the associated GitHub account.
 
+Folders nest: work/clients/acme is a folder inside work/clients and work.
+Whatever a nested folder doesn't set — account, forge, git identity,
+visibility — comes from the nearest configured parent, and its tags are
+added to the parents'. 'projects folder show' prints the result.
+
 If --account is omitted and gh is authenticated, you'll be prompted to
-pick from your logged-in accounts.`,
-		Args: cobra.ExactArgs(1),
+pick from your logged-in accounts.
+
+--author-name, --author-email, --signing-key and --ssh-command set the git
+identity for the folder's projects. They are written to each project's local
+git config on create, first push and move, so work commits don't go out with
+your personal email. A signing key turns on commit signing (SSH keys ending
+in .pub also set gpg.format=ssh).
+
+--tags are added to every project created in the folder, and --visibility
+public makes the repos push creates public unless --private is given.
+
+--forge gitlab|gitea|forgejo puts the folder on another forge, with
+--base-url for self-hosted instances (required for Gitea and Forgejo). The
+API token comes from the variable named by --token-env, or GITLAB_TOKEN,
+GITEA_TOKEN or FORGEJO_TOKEN. --account is the user or group to create repos
+under and defaults to the token's user.`,
+		Example: "  projects folder add work --account octo-work --author-email me@work.example \\\n    --ssh-command \"ssh -i ~/.ssh/id_work\"\n  projects folder add club --forge forgejo --base-url https://codeberg.org --token-env CODEBERG_TOKEN",
+		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
 			if !ok {
 				return fmt.Errorf("missing runtime context")
 			}
+			ctx := cmd.Context()
+			forge := runtime.ForgeBackend()
 
 			name := args[0]
 
-			// Validate the folder name using slug rules.
-			if err := ValidateSlug(name); err != nil {
-				return fmt.Errorf("invalid folder name: %w", err)
+			if err := validateFolderName(name); err != nil {
+				return err
 			}
 
 			// Check for duplicate folder name.
 			if runtime.Config.FolderByName(name) != nil {
 				return fmt.Errorf("folder %q already exists", name)
 			}
+			if err := validateVisibility(identity.Visibility); err != nil {
+				return err
+			}
 
-			// Resolve the account: flag > interactive picker > error.
-			if account == "" {
-				picked, err := pickGHAccount(cmd)
+			// A nested folder inherits what it leaves unset from its parents.
+			identity.Name = name
+			trial := runtime.Config
+			trial.Folders = append(slices.Clip(trial.Folders), identity)
+			folder := trial.FolderByName(name)
+			if _, err := folderForge(folder); err != nil {
+				return err
+			}
+
+			// Resolve the account: flag > parent folder > interactive picker > error.
+			inherited := account == "" && folder.GitHubAccount != ""
+			switch {
+			case inherited:
+				account = folder.GitHubAccount
+			case !usesGHCLI(folder):
+				picked, err := checkForgeAccount(cmd, runtime, folder, account)
+				if err != nil {
+					return err
+				}
+				account = picked
+			case account == "":
+				picked, err := pickGHAccount(cmd, forge)
 				if err != nil {
 					return err
 				}
@@ -78,42 +138,64 @@ pick from your logged-in accounts.`,
 
 			// Warn (don't block) if gh isn't set up — the folder is still useful
 			// as config, and auth can be sorted out before the first push.
-			if !git.HasGHCLI() {
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage("gh CLI not found — install it and run 'gh auth login' before pushing"))
-			} else if accounts := git.ListAuthAccounts(); len(accounts) > 0 && !git.IsAuthAccount(account) {
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(
-					fmt.Sprintf("account %q not found in gh auth (have: %s) — run 'gh auth login' to add it",
-						account, strings.Join(accounts, ", "))))
+			// Other forges were checked by checkForgeAccount above.
+			if usesGHCLI(folder) && !inherited {
+				if !forge.HasCLI(ctx) {
+					fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage("gh CLI not found — install it and run 'gh auth login' before pushing"))
+				} else if accounts := forge.ListAuthAccounts(ctx); len(accounts) > 0 && !forge.IsAuthAccount(ctx, account) {
+					fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(
+						fmt.Sprintf("account %q not found in gh auth (have: %s) — run 'gh auth login' to add it",
+							account, strings.Join(accounts, ", "))))
+				}
 			}
 
 			// Create the folder directory.
-			folderDir := filepath.Join(runtime.Config.ProjectsDir, name)
+			folderDir := filepath.Join(runtime.Config.ProjectsDir, filepath.FromSlash(name))
+			if _, err := os.Stat(project.ProjectFilePath(folderDir)); err == nil {
+				return fmt.Errorf("%s is a project, not a folder", folderDir)
+			}
 			if err := os.MkdirAll(folderDir, 0755); err != nil {
 				return fmt.Errorf("create folder directory: %w", err)
 			}
 
 			// Add to config and save.
-			runtime.Config.Folders = append(runtime.Config.Folders, config.Folder{
-				Name:          name,
-				GitHubAccount: account,
-			})
+			if !inherited {
+				identity.GitHubAccount = account
+			}
+			folder.GitHubAccount = account
+			runtime.Config.Folders = append(runtime.Config.Folders, identity)
 
 			if err := config.SaveToPath(runtime.Config, runtime.ConfigPath); err != nil {
 				return fmt.Errorf("save config: %w", err)
 			}
 
 			if tui.IsJSON() {
-				return writeJSON(cmd.OutOrStdout(), map[string]string{
+				out := map[string]string{
 					"status":         "created",
 					"folder":         name,
 					"github_account": account,
 					"path":           folderDir,
-				})
+				}
+				if folder.Forge != "" {
+					out["forge"] = folder.Forge
+				}
+				if folder.BaseURL != "" {
+					out["base_url"] = folder.BaseURL
+				}
+				return writeJSON(cmd.OutOrStdout(), out)
 			}
 
 			w := cmd.OutOrStdout()
 			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Folder %s created — %s", tui.Slug(name), tui.RandomFolderCheer())))
-			fmt.Fprintln(w, tui.FormatField("GitHub account", tui.Slug(account)))
+			if usesGHCLI(folder) {
+				fmt.Fprintln(w, tui.FormatField("GitHub account", tui.Slug(account)))
+			} else {
+				fmt.Fprintln(w, tui.FormatField("Forge", folderForgeLabel(folder)))
+				fmt.Fprintln(w, tui.FormatField("Account", tui.Slug(account)))
+			}
+			for _, s := range folderGitSettings(folder) {
+				fmt.Fprintln(w, tui.FormatField(s.Key, s.Value))
+			}
 			fmt.Fprintln(w, tui.FormatField("Path", tui.Path(folderDir)))
 			fmt.Fprintln(w)
 			fmt.Fprintln(w, tui.InfoMessage(fmt.Sprintf("Create projects here with: projects create <slug> --folder %s", name)))
@@ -121,19 +203,98 @@ pick from your logged-in accounts.`,
 		},
 	}
 
-	cmd.Flags().StringVar(&account, "account", "", "GitHub username/account for this folder")
+	cmd.Flags().StringVar(&account, "account", "", "GitHub username/account (or GitLab/Gitea user or group) for this folder")
+	cmd.Flags().StringVar(&identity.Forge, "forge", "", "forge hosting the folder's repos: github, gitlab, gitea or forgejo (default github)")
+	cmd.Flags().StringVar(&identity.BaseURL, "base-url", "", "web root of a self-hosted forge, e.g. https://git.example.com")
+	cmd.Flags().StringVar(&identity.TokenEnv, "token-env", "", "environment variable holding the forge API token")
+	cmd.Flags().StringVar(&identity.AuthorName, "author-name", "", "git user.name for the folder's projects")
+	cmd.Flags().StringVar(&identity.AuthorEmail, "author-email", "", "git user.email for the folder's projects")
+	cmd.Flags().StringVar(&identity.SigningKey, "signing-key", "", "GPG key ID or SSH public key file to sign commits with")
+	cmd.Flags().StringVar(&identity.SSHCommand, "ssh-command", "", "git core.sshCommand for the folder's projects (e.g. \"ssh -i ~/.ssh/id_work\")")
+	cmd.Flags().StringSliceVar(&identity.Tags, "tags", nil, "tags added to projects created in the folder (comma-separated)")
+	cmd.Flags().StringVar(&identity.Visibility, "visibility", "", "visibility of repos created from the folder: private or public (default private)")
 
 	return cmd
 }
 
+// validateFolderName checks each /-separated part of a folder name against
+// the slug rules.
+func validateFolderName(name string) error {
+	for _, part := range strings.Split(name, "/") {
+		if err := ValidateSlug(part); err != nil {
+			return fmt.Errorf("invalid folder name %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Folder visibilities for the repos push creates.
+const (
+	visibilityPrivate = "private"
+	visibilityPublic  = "public"
+)
+
+func validateVisibility(v string) error {
+	switch v {
+	case "", visibilityPrivate, visibilityPublic:
+		return nil
+	}
+	return fmt.Errorf("--visibility must be %q or %q", visibilityPrivate, visibilityPublic)
+}
+
+// checkForgeAccount validates a GitLab/Gitea folder against its forge. An
+// empty account defaults to the token's user; a given one that the token
+// can't create repos under is warned about but kept.
+func checkForgeAccount(cmd *cobra.Command, runtime RuntimeContext, folder *config.Folder, account string) (string, error) {
+	ctx := cmd.Context()
+	p, err := providerFor(ctx, runtime, folder)
+	if err != nil {
+		return "", err
+	}
+	accounts, err := p.Accounts(ctx)
+	if err != nil {
+		if account == "" {
+			return "", fmt.Errorf("--account is required (could not list %s accounts: %w)", p.Kind(), err)
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("could not check account %q on %s: %v", account, p.Kind(), err)))
+		return account, nil
+	}
+	if account == "" {
+		fmt.Fprintln(cmd.ErrOrStderr(), tui.Muted(fmt.Sprintf("Using %s account %q", p.Kind(), accounts[0])))
+		return accounts[0], nil
+	}
+	for _, a := range accounts {
+		if strings.EqualFold(a, account) {
+			return account, nil
+		}
+	}
+	fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(
+		fmt.Sprintf("the %s token can't create repos under %q (have: %s)", p.Kind(), account, strings.Join(accounts, ", "))))
+	return account, nil
+}
+
+// folderForgeLabel describes where folder's repos live, e.g. "gitea
+// (https://git.example.com)".
+func folderForgeLabel(folder *config.Folder) string {
+	kind, err := folderForge(folder)
+	if err != nil {
+		return folder.Forge
+	}
+	if folder.BaseURL != "" {
+		return fmt.Sprintf("%s (%s)", kind, folder.BaseURL)
+	}
+	return string(kind)
+}
+
 // pickGHAccount tries to interactively pick a gh account. Falls back to
 // a clear error message if non-interactive or gh isn't available.
-func pickGHAccount(cmd *cobra.Command) (string, error) {
-	if !git.HasGHCLI() {
+func pickGHAccount(cmd *cobra.Command, forge git.Forge) (string, error) {
+	ctx := cmd.Context()
+	if !forge.HasCLI(ctx) {
 		return "", fmt.Errorf("--account is required (gh CLI not available for interactive selection)")
 	}
 
-	accounts := git.ListAuthAccounts()
+	accounts := forge.ListAuthAccounts(ctx)
 	if len(accounts) == 0 {
 		return "", fmt.Errorf("--account is required (no accounts found in gh auth)\n\nRun 'gh auth login' first, or pass --account <username>")
 	}
@@ -197,11 +358,12 @@ func newFolderListCmd() *cobra.Command {
 			fmt.Fprintln(w, tui.Header("📂 Your Folders"))
 			fmt.Fprintln(w)
 
-			headers := []string{"Name", "GitHub Account", "Path"}
+			headers := []string{"Name", "Account", "Forge", "Path"}
 			var rows [][]string
 			for _, f := range folders {
-				path := filepath.Join(runtime.Config.ProjectsDir, f.Name)
-				rows = append(rows, []string{f.Name, f.GitHubAccount, path})
+				eff := runtime.Config.FolderByName(f.Name)
+				path := filepath.Join(runtime.Config.ProjectsDir, filepath.FromSlash(f.Name))
+				rows = append(rows, []string{f.Name, eff.GitHubAccount, folderForgeLabel(eff), path})
 			}
 
 			fmt.Fprintln(w, tui.Table(headers, rows))
@@ -217,7 +379,9 @@ func newFolderRemoveCmd() *cobra.Command {
 		Use:     "remove <name>",
 		Aliases: []string{"rm"},
 		Short:   "Remove a folder configuration",
-		Long:    "Remove a folder from the config. Does not delete the directory or its projects.",
+		Long: `Remove a folder from the config. Does not delete the directory or its
+projects. A folder with subfolders can't be removed, since they inherit its
+settings; remove or move them first.`,
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
@@ -230,17 +394,24 @@ func newFolderRemoveCmd() *cobra.Command {
 			// Find and remove the folder from config.
 			found := false
 			var remaining []config.Folder
+			var children []string
 			for _, f := range runtime.Config.Folders {
 				if f.Name == name {
 					found = true
 					continue
 				}
+				if strings.HasPrefix(f.Name, name+"/") {
+					children = append(children, f.Name)
+				}
 				remaining = append(remaining, f)
 			}
 
 			if !found {
 				return fmt.Errorf("folder %q not found", name)
 			}
+			if len(children) > 0 {
+				return fmt.Errorf("folder %q has subfolders that inherit its settings (%s); remove them or move them with 'projects folder move' first", name, strings.Join(children, ", "))
+			}
 
 			runtime.Config.Folders = remaining
 			if err := config.SaveToPath(runtime.Config, runtime.ConfigPath); err != nil {
@@ -263,3 +434,64 @@ func newFolderRemoveCmd() *cobra.Command {
 
 	return cmd
 }
+
+func newFolderApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Write the folder's git identity into its projects",
+		Long: `Write the folder's author, signing and SSH settings into the local git config
+of every git project in the folder. Use it after changing them in the config
+or when 'projects status' reports identity drift.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			folder := runtime.Config.FolderByName(args[0])
+			if folder == nil {
+				return fmt.Errorf("folder %q not found", args[0])
+			}
+			if len(folderGitSettings(folder)) == 0 {
+				return fmt.Errorf("folder %q has no author, signing or SSH settings to apply", folder.Name)
+			}
+
+			projects, err := listAllProjects(runtime.Config, folder.Name)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			g := runtime.GitBackend()
+			applied := []string{}
+			for _, p := range projects {
+				if !g.IsRepo(ctx, p.Dir) {
+					continue
+				}
+				if err := applyFolderIdentity(ctx, g, p.Dir, folder, nil); err != nil {
+					return fmt.Errorf("%s: %w", p.Meta.Slug, err)
+				}
+				applied = append(applied, p.Meta.Slug)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"status":   "applied",
+					"folder":   folder.Name,
+					"projects": applied,
+				})
+			}
+			w := cmd.OutOrStdout()
+			if len(applied) == 0 {
+				fmt.Fprintln(w, tui.Muted(fmt.Sprintf("No git projects in folder %s.", folder.Name)))
+				return nil
+			}
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Applied %s's git identity to %d project(s)", tui.Slug(folder.Name), len(applied))))
+			for _, s := range folderGitSettings(folder) {
+				fmt.Fprintln(w, tui.FormatField(s.Key, s.Value))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
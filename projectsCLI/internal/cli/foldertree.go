@@ -0,0 +1,312 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// folderSetting is one effective folder setting and the folder it comes from.
+type folderSetting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// From is the folder that sets it, or "default" for built-in defaults.
+	From string `json:"from"`
+}
+
+type folderShowResult struct {
+	Name     string          `json:"name"`
+	Path     string          `json:"path"`
+	Parent   string          `json:"parent,omitempty"`
+	Children []string        `json:"children,omitempty"`
+	Settings []folderSetting `json:"settings"`
+	Projects int             `json:"projects"`
+}
+
+// folderFields are the inherited folder settings, in display order.
+var folderFields = []struct {
+	key string
+	get func(config.Folder) string
+	def string
+}{
+	{"github_account", func(f config.Folder) string { return f.GitHubAccount }, ""},
+	{"forge", func(f config.Folder) string { return f.Forge }, "github"},
+	{"base_url", func(f config.Folder) string { return f.BaseURL }, ""},
+	{"token_env", func(f config.Folder) string { return f.TokenEnv }, ""},
+	{"author_name", func(f config.Folder) string { return f.AuthorName }, ""},
+	{"author_email", func(f config.Folder) string { return f.AuthorEmail }, ""},
+	{"signing_key", func(f config.Folder) string { return f.SigningKey }, ""},
+	{"ssh_command", func(f config.Folder) string { return f.SSHCommand }, ""},
+	{"visibility", func(f config.Folder) string { return f.Visibility }, visibilityPrivate},
+}
+
+func newFolderShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show a folder's effective settings",
+		Long: `Show the settings that apply to a folder's projects, after inheriting from
+its parent folders, and which folder each one comes from.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			name := args[0]
+			folder := runtime.Config.FolderByName(name)
+			if folder == nil {
+				return fmt.Errorf("folder %q not found", name)
+			}
+			chain := runtime.Config.FolderChain(name)
+
+			res := folderShowResult{
+				Name:     name,
+				Path:     filepath.Join(runtime.Config.ProjectsDir, filepath.FromSlash(name)),
+				Settings: []folderSetting{},
+			}
+			if len(chain) > 1 {
+				res.Parent = chain[len(chain)-2].Name
+			}
+			for _, f := range runtime.Config.Folders {
+				if strings.HasPrefix(f.Name, name+"/") {
+					res.Children = append(res.Children, f.Name)
+				}
+			}
+			for _, field := range folderFields {
+				if s, ok := inheritedSetting(chain, field.key, field.get, field.def); ok {
+					res.Settings = append(res.Settings, s)
+				}
+			}
+			if len(folder.Tags) > 0 {
+				var from []string
+				for _, f := range chain {
+					if len(f.Tags) > 0 {
+						from = append(from, f.Name)
+					}
+				}
+				res.Settings = append(res.Settings, folderSetting{"tags", strings.Join(folder.Tags, ","), strings.Join(from, ",")})
+			}
+			projects, err := listAllProjects(runtime.Config, name)
+			if err != nil {
+				return err
+			}
+			res.Projects = len(projects)
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), res)
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.Header("📂 "+name))
+			fmt.Fprintln(w, tui.FormatField("Path", tui.Path(res.Path)))
+			if res.Parent != "" {
+				fmt.Fprintln(w, tui.FormatField("Parent", tui.Slug(res.Parent)))
+			}
+			if len(res.Children) > 0 {
+				fmt.Fprintln(w, tui.FormatField("Subfolders", strings.Join(res.Children, ", ")))
+			}
+			fmt.Fprintln(w, tui.FormatField("Projects", fmt.Sprint(res.Projects)))
+			fmt.Fprintln(w)
+			for _, s := range res.Settings {
+				value := s.Value
+				if s.From != name {
+					value += tui.Muted(" (from " + s.From + ")")
+				}
+				fmt.Fprintln(w, tui.FormatField(s.Key, value))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// inheritedSetting finds the nearest folder in chain that sets key, falling
+// back to def.
+func inheritedSetting(chain []config.Folder, key string, get func(config.Folder) string, def string) (folderSetting, bool) {
+	for i := len(chain) - 1; i >= 0; i-- {
+		if v := get(chain[i]); v != "" {
+			return folderSetting{key, v, chain[i].Name}, true
+		}
+	}
+	if def != "" {
+		return folderSetting{key, def, "default"}, true
+	}
+	return folderSetting{}, false
+}
+
+type folderRelocateResult struct {
+	Status string `json:"status"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Path   string `json:"path"`
+	// Folders lists the new names of the folder and its subfolders.
+	Folders []string `json:"folders"`
+	// Reapplied lists projects whose git identity changed with the new
+	// parent and was rewritten.
+	Reapplied []string `json:"reapplied"`
+}
+
+func newFolderRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <name> <new-name>",
+		Short: "Rename a folder and its directory",
+		Long: `Rename a folder, keeping it under the same parent: 'folder rename
+work/clients/acme acme-corp' gives work/clients/acme-corp. The directory is
+renamed and the folder and its subfolders are updated in the config; if
+saving the config fails the directory is renamed back.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, leaf := args[0], args[1]
+			if strings.Contains(leaf, "/") {
+				return fmt.Errorf("new name %q must not contain /; use 'projects folder move' to change the parent", leaf)
+			}
+			to := leaf
+			if parent := path.Dir(from); parent != "." {
+				to = parent + "/" + leaf
+			}
+			return relocateFolder(cmd, from, to, "renamed")
+		},
+	}
+
+	return cmd
+}
+
+func newFolderMoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "move <name> <parent>",
+		Short: "Move a folder under another parent",
+		Long: `Move a folder and its directory under another parent folder, or to the top
+level with "". Subfolders move with it, and projects whose inherited git
+identity changes get the new one written to their local git config. If
+saving the config fails the directory is moved back.`,
+		Example: "  projects folder move acme work/clients\n  projects folder move work/clients/acme \"\"",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, parent := args[0], strings.Trim(args[1], "/")
+			to := path.Base(from)
+			if parent != "" {
+				to = parent + "/" + to
+			}
+			return relocateFolder(cmd, from, to, "moved")
+		},
+	}
+
+	return cmd
+}
+
+// relocateFolder renames folder from, and its subfolders, to to: the
+// directory first, then the config, putting the directory back if the
+// config can't be saved.
+func relocateFolder(cmd *cobra.Command, from, to, status string) error {
+	runtime, ok := RuntimeFromContext(cmd.Context())
+	if !ok {
+		return fmt.Errorf("missing runtime context")
+	}
+	ctx := cmd.Context()
+	cfg := runtime.Config
+
+	if cfg.FolderByName(from) == nil {
+		return fmt.Errorf("folder %q not found", from)
+	}
+	if err := validateFolderName(to); err != nil {
+		return err
+	}
+	switch {
+	case to == from:
+		return fmt.Errorf("folder %q is already there", from)
+	case strings.HasPrefix(to, from+"/"):
+		return fmt.Errorf("can't move folder %q inside itself", from)
+	case cfg.FolderByName(to) != nil:
+		return fmt.Errorf("folder %q already exists", to)
+	}
+	oldDir := filepath.Join(cfg.ProjectsDir, filepath.FromSlash(from))
+	newDir := filepath.Join(cfg.ProjectsDir, filepath.FromSlash(to))
+	if _, err := os.Lstat(newDir); err == nil {
+		return fmt.Errorf("destination already exists: %s", newDir)
+	}
+
+	updated := cfg
+	updated.Folders = slices.Clone(cfg.Folders)
+	renamed := map[string]string{} // new name -> old name
+	res := folderRelocateResult{Status: status, From: from, To: to, Path: newDir, Reapplied: []string{}}
+	for i, f := range updated.Folders {
+		if f.Name == from || strings.HasPrefix(f.Name, from+"/") {
+			name := to + strings.TrimPrefix(f.Name, from)
+			updated.Folders[i].Name = name
+			renamed[name] = f.Name
+			res.Folders = append(res.Folders, name)
+		}
+	}
+
+	slices.Sort(res.Folders)
+
+	moved := false
+	if _, err := os.Stat(oldDir); err == nil {
+		if err := os.MkdirAll(filepath.Dir(newDir), 0755); err != nil {
+			return fmt.Errorf("create parent directory: %w", err)
+		}
+		if err := os.Rename(oldDir, newDir); err != nil {
+			return fmt.Errorf("move folder directory: %w", err)
+		}
+		moved = true
+	}
+	if err := config.SaveToPath(updated, runtime.ConfigPath); err != nil {
+		if moved {
+			if undo := os.Rename(newDir, oldDir); undo != nil {
+				return fmt.Errorf("save config: %w (and moving %s back to %s failed: %v)", err, newDir, oldDir, undo)
+			}
+		}
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	// Regenerate registry.
+	_ = project.WriteRegistry(updated.ProjectsDir, updated.Links...)
+
+	// A new parent can change the inherited git identity.
+	g := runtime.GitBackend()
+	for _, name := range res.Folders {
+		before, after := cfg.FolderByName(renamed[name]), updated.FolderByName(name)
+		if slices.Equal(folderGitSettings(before), folderGitSettings(after)) {
+			continue
+		}
+		projects, err := listAllProjects(updated, name)
+		if err != nil {
+			continue
+		}
+		for _, p := range projects {
+			if !g.IsRepo(ctx, p.Dir) {
+				continue
+			}
+			if err := applyFolderIdentity(ctx, g, p.Dir, after, before); err != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("%s: apply folder git identity: %v", p.Meta.Slug, err)))
+				continue
+			}
+			res.Reapplied = append(res.Reapplied, p.Meta.Slug)
+		}
+	}
+
+	if tui.IsJSON() {
+		return writeJSON(cmd.OutOrStdout(), res)
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Folder %s %s to %s", tui.Slug(from), status, tui.Slug(to))))
+	fmt.Fprintln(w, tui.FormatField("Path", tui.Path(newDir)))
+	if len(res.Folders) > 1 {
+		fmt.Fprintln(w, tui.FormatField("Subfolders", strings.Join(res.Folders[1:], ", ")))
+	}
+	if len(res.Reapplied) > 0 {
+		fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  Updated the git identity of %d project(s) for the new parent.", len(res.Reapplied))))
+	}
+	return nil
+}
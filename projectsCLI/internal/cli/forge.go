@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+// tokenEnvs lists the environment variables checked for each forge's API
+// token when the folder doesn't name its own.
+var tokenEnvs = map[forge.Kind][]string{
+	forge.GitHub: {"GH_TOKEN", "GITHUB_TOKEN"},
+	forge.GitLab: {"GITLAB_TOKEN"},
+	forge.Gitea:  {"GITEA_TOKEN", "FORGEJO_TOKEN"},
+}
+
+// folderForge returns the forge kind of folder; nil means the root projects
+// directory, which is always GitHub.
+func folderForge(folder *config.Folder) (forge.Kind, error) {
+	if folder == nil {
+		return forge.GitHub, nil
+	}
+	return forge.ParseKind(folder.Forge)
+}
+
+// usesGHCLI reports whether repos for folder are created through gh rather
+// than a forge's REST API. GitHub Enterprise folders, which set a base_url,
+// go through the API since gh acts on github.com.
+func usesGHCLI(folder *config.Folder) bool {
+	kind, err := folderForge(folder)
+	return err == nil && kind == forge.GitHub && (folder == nil || folder.BaseURL == "")
+}
+
+// folderAccount is the forge account that owns folder's repos; nil means the
+// root projects directory, which uses the configured GitHub username.
+func folderAccount(cfg config.Config, folder *config.Folder) string {
+	if folder != nil && folder.GitHubAccount != "" {
+		return folder.GitHubAccount
+	}
+	return cfg.GitHubUsername
+}
+
+// forgeToken finds an API token for folder: the folder's token_env, then (on
+// GitHub) the gh token for its account, then the forge's usual variables.
+// An empty token is not an error; public reads still work without one.
+func forgeToken(ctx context.Context, runtime RuntimeContext, folder *config.Folder, kind forge.Kind) (string, error) {
+	if folder != nil && folder.TokenEnv != "" {
+		if token := os.Getenv(folder.TokenEnv); token != "" {
+			return token, nil
+		}
+		return "", fmt.Errorf("folder %q reads its token from $%s, which is not set", folder.Name, folder.TokenEnv)
+	}
+	if usesGHCLI(folder) {
+		if account := folderAccount(runtime.Config, folder); account != "" {
+			if token, err := runtime.ForgeBackend().AuthToken(ctx, account); err == nil {
+				return token, nil
+			}
+		}
+	}
+	for _, env := range tokenEnvs[kind] {
+		if token := os.Getenv(env); token != "" {
+			return token, nil
+		}
+	}
+	return "", nil
+}
+
+// providerFor returns the forge API client for folder's projects.
+func providerFor(ctx context.Context, runtime RuntimeContext, folder *config.Folder) (forge.Provider, error) {
+	kind, err := folderForge(folder)
+	if err != nil {
+		return nil, err
+	}
+	token, err := forgeToken(ctx, runtime, folder, kind)
+	if err != nil {
+		return nil, err
+	}
+	cfg := forge.Config{Kind: kind, Token: token}
+	if folder != nil {
+		cfg.BaseURL = folder.BaseURL
+	}
+	return forge.New(cfg)
+}
+
+// forgeTokenEnv names the environment variable forgeToken reads folder's
+// token from, or "" if none is set.
+func forgeTokenEnv(folder *config.Folder, kind forge.Kind) string {
+	if folder != nil && folder.TokenEnv != "" {
+		return folder.TokenEnv
+	}
+	for _, env := range tokenEnvs[kind] {
+		if os.Getenv(env) != "" {
+			return env
+		}
+	}
+	return ""
+}
+
+// createForgeRepo creates proj's repository through the folder's forge API
+// and points origin at it: over SSH when the folder has an SSH command,
+// otherwise over HTTPS with a credential helper that reads the same token
+// variable the API call used, so the first push doesn't prompt.
+func createForgeRepo(ctx context.Context, runtime RuntimeContext, proj *project.Project, folder *config.Folder, private bool) (*forge.Repo, error) {
+	p, err := providerFor(ctx, runtime, folder)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := p.CreateRepo(ctx, forge.CreateOptions{
+		Owner:       folder.GitHubAccount,
+		Name:        proj.Meta.Slug,
+		Private:     private,
+		Description: proj.Meta.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s repo: %w", p.Kind(), err)
+	}
+	g := runtime.GitBackend()
+	origin := repo.CloneURL
+	if folder.SSHCommand != "" && repo.SSHURL != "" {
+		origin = repo.SSHURL
+	} else if env := forgeTokenEnv(folder, p.Kind()); env != "" {
+		if err := setTokenCredentials(ctx, g, proj.Dir, origin, folder.GitHubAccount, env); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.SetRemote(ctx, proj.Dir, "origin", origin); err != nil {
+		return nil, fmt.Errorf("add remote: %w", err)
+	}
+	return repo, nil
+}
+
+// setTokenCredentials configures the repo at dir to answer credential
+// requests for cloneURL's host with the token in $env. Only the variable's
+// name is stored in the git config, never the token.
+func setTokenCredentials(ctx context.Context, g git.Git, dir, cloneURL, account, env string) error {
+	for _, kv := range tokenCredentials(cloneURL, account, env) {
+		if err := g.SetLocalConfig(ctx, dir, kv[0], kv[1]); err != nil {
+			return fmt.Errorf("set %s: %w", kv[0], err)
+		}
+	}
+	return nil
+}
+
+// tokenCredentials returns the git config setTokenCredentials writes, or
+// nil when cloneURL isn't an HTTP(S) URL or env isn't a variable name.
+func tokenCredentials(cloneURL, account, env string) [][2]string {
+	u, err := url.Parse(cloneURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || !shellNameRegexp.MatchString(env) {
+		return nil
+	}
+	if account == "" {
+		account = "git"
+	}
+	scope := "credential." + u.Scheme + "://" + u.Host
+	return [][2]string{
+		{scope + ".username", account},
+		{scope + ".helper", fmt.Sprintf(`!f() { test "$1" = get && echo "password=$%s"; }; f`, env)},
+	}
+}
+
+// matchingOrigin is the clone URL of repo in the same form (HTTPS or SSH)
+// as the origin it replaces, HTTPS when there is none.
+func matchingOrigin(old string, repo *forge.Repo) string {
+	if old == "" || strings.HasPrefix(old, "http://") || strings.HasPrefix(old, "https://") || repo.SSHURL == "" {
+		return repo.CloneURL
+	}
+	return repo.SSHURL
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+)
+
+// withGHAccount returns a context whose gh and git subprocesses act as
+// account. When gh can hand out the account's token it is passed as GH_TOKEN
+// and gh's globally active account is left alone. Older gh versions fall back
+// to `gh auth switch`, and restore switches back to the previously active
+// account. restore is never nil and must always be called.
+func withGHAccount(ctx context.Context, forge git.Forge, account string) (_ context.Context, restore func(), err error) {
+	restore = func() {}
+	if account == "" {
+		return ctx, restore, nil
+	}
+	if token, err := forge.AuthToken(ctx, account); err == nil {
+		return git.WithGHToken(ctx, token), restore, nil
+	}
+
+	previous := forge.ActiveAccount(ctx)
+	if strings.EqualFold(previous, account) {
+		return ctx, restore, nil
+	}
+	if err := forge.SwitchAuth(ctx, account); err != nil {
+		return ctx, restore, fmt.Errorf("could not switch to GitHub account %q — is it authenticated? Run 'gh auth login' to add it: %w", account, err)
+	}
+	if previous != "" {
+		restore = func() {
+			// Switch back even if the command was interrupted.
+			_ = forge.SwitchAuth(context.WithoutCancel(ctx), previous)
+		}
+	}
+	return ctx, restore, nil
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
@@ -138,11 +139,19 @@ func findProject(cfg config.Config, slug string, folderHint string) (*project.Pr
 		}
 	}
 
+	// Search external projects.
+	for _, proj := range project.ListLinked(cfg.Links) {
+		if proj.Meta.Slug == slug {
+			return proj, nil
+		}
+	}
+
 	return nil, fmt.Errorf("project %q not found", slug)
 }
 
-// listAllProjects lists projects from the top-level and all configured folders.
-// If folderHint is non-empty, only that folder is listed.
+// listAllProjects lists projects from the top-level, all configured folders
+// and the external links. If folderHint is non-empty, only that folder is
+// listed.
 func listAllProjects(cfg config.Config, folderHint string) ([]*project.Project, error) {
 	if folderHint != "" {
 		folderDir := filepath.Join(cfg.ProjectsDir, folderHint)
@@ -175,6 +184,18 @@ func listAllProjects(cfg config.Config, folderHint string) ([]*project.Project,
 		}
 	}
 
+	// Collect external projects, skipping any already symlinked in.
+	seen := make(map[string]bool, len(all))
+	for _, p := range all {
+		seen[project.RealDir(p.Dir)] = true
+	}
+	for _, p := range project.ListLinked(cfg.Links) {
+		if dir := project.RealDir(p.Dir); !seen[dir] {
+			seen[dir] = true
+			all = append(all, p)
+		}
+	}
+
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Meta.Slug < all[j].Meta.Slug
 	})
@@ -182,6 +203,36 @@ func listAllProjects(cfg config.Config, folderHint string) ([]*project.Project,
 	return all, nil
 }
 
+// folderLabel is what the Folder column of list and status shows for a
+// project.
+func folderLabel(folder string, external bool) string {
+	switch {
+	case external && folder != "":
+		return folder + " (external)"
+	case external:
+		return "external"
+	case folder != "":
+		return folder
+	default:
+		return "-"
+	}
+}
+
+// folderTags returns folder's default tags followed by the other tags, without
+// duplicates.
+func folderTags(folder *config.Folder, tags []string) []string {
+	if folder == nil || len(folder.Tags) == 0 {
+		return tags
+	}
+	merged := append([]string(nil), folder.Tags...)
+	for _, t := range tags {
+		if !slices.Contains(merged, t) {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
 // folderForProject returns the folder config for a project, or nil if it's top-level.
 func folderForProject(cfg config.Config, proj *project.Project) *config.Folder {
 	if proj.Folder == "" {
@@ -1,6 +1,10 @@
 package cli
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+)
 
 func TestSlugify(t *testing.T) {
 	tests := []struct {
@@ -45,3 +49,46 @@ func TestValidateSlug(t *testing.T) {
 		}
 	}
 }
+
+func TestUsesGHCLI(t *testing.T) {
+	tests := []struct {
+		name   string
+		folder *config.Folder
+		want   bool
+	}{
+		{"root", nil, true},
+		{"github", &config.Folder{Name: "work"}, true},
+		{"enterprise", &config.Folder{Name: "corp", Forge: "github", BaseURL: "https://ghe.example.com"}, false},
+		{"gitlab", &config.Folder{Name: "lab", Forge: "gitlab"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := usesGHCLI(tt.folder); got != tt.want {
+			t.Errorf("%s: usesGHCLI = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSameForge(t *testing.T) {
+	work := &config.Folder{Name: "work"}
+	lab := &config.Folder{Name: "lab", Forge: "gitlab"}
+	tea := &config.Folder{Name: "tea", Forge: "gitea", BaseURL: "https://tea.example.com"}
+	club := &config.Folder{Name: "club", Forge: "forgejo", BaseURL: "https://tea.example.com/"}
+	other := &config.Folder{Name: "other", Forge: "gitea", BaseURL: "https://other.example.com"}
+
+	tests := []struct {
+		from, to *config.Folder
+		want     bool
+	}{
+		{nil, work, true},
+		{work, lab, false},
+		{tea, club, true},
+		{tea, other, false},
+	}
+
+	for _, tt := range tests {
+		if got := sameForge(tt.from, tt.to); got != tt.want {
+			t.Errorf("sameForge(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
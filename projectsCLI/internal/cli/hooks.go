@@ -0,0 +1,405 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/hooks"
+	"github.com/jackmorganxyz/projectsCLI/internal/secrets"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// preCommitBypassEnv, when set in a commit's environment, makes the managed
+// pre-commit hook pass without running its checks.
+const preCommitBypassEnv = "PROJECTS_SKIP_PRE_COMMIT"
+
+// Install actions, reported per hook by hooks install.
+const (
+	hookInstalled = "installed" // there was no hook
+	hookUpdated   = "updated"   // an older managed version was replaced
+	hookUnchanged = "unchanged" // already current
+	hookReplaced  = "replaced"  // a foreign hook was kept as <name>.local
+	hookSkipped   = "skipped"   // a foreign hook is in the way
+)
+
+// hookState is one hook's state in a project.
+type hookState struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Version int    `json:"version,omitempty"`
+}
+
+// hooksResult is the outcome of hooks install or status for one project.
+type hooksResult struct {
+	Slug   string      `json:"slug"`
+	Folder string      `json:"folder,omitempty"`
+	Hooks  []hookState `json:"hooks,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// NewHooksCmd creates the hooks command group for managed git hooks.
+func NewHooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage the git hooks installed in projects",
+		Long: fmt.Sprintf(`Install and inspect the git hooks projects manages (pre-commit and
+commit-msg).
+
+pre-commit blocks staged files under private/ and anything that looks like a
+secret, using the same rules and %s allowlist as 'projects scan'.
+commit-msg enforces commit_msg_pattern from the config, a regular expression
+the subject line must match (merge, revert and fixup commits are exempt).
+
+The hooks are small scripts that call 'projects hooks run', and carry a
+version so 'hooks status' can tell which projects need reinstalling. create
+installs them automatically unless install_hooks = false. Bypass them for one
+commit with 'git commit --no-verify', or skip just the pre-commit checks by
+setting %s=1 ('push --force' does this for its commit).`, secrets.AllowlistFile, preCommitBypassEnv),
+	}
+
+	cmd.AddCommand(
+		newHooksInstallCmd(),
+		newHooksStatusCmd(),
+		newHooksRunCmd(),
+	)
+
+	return cmd
+}
+
+func newHooksInstallCmd() *cobra.Command {
+	var (
+		all   bool
+		where []string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install [slug]",
+		Short: "Install or update the managed hooks",
+		Long: `Install or update the managed hooks in a project, or in every matching git
+project with --all or --where. A hook projects didn't write is left alone
+unless --force is given, in which case it is renamed to <hook>.local and the
+managed hook runs it first. An existing <hook>.local is never overwritten.`,
+		Example: "  projects hooks install my-app\n  projects hooks install --all",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			projects, err := selectProjects(runtime, args, all, where)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			g := runtime.GitBackend()
+			var results []hooksResult
+			failed, skipped := 0, 0
+			for _, p := range projects {
+				if !g.IsRepo(ctx, p.Dir) {
+					if len(args) == 1 {
+						return fmt.Errorf("project %q has no git repository", p.Meta.Slug)
+					}
+					continue
+				}
+				res := hooksResult{Slug: p.Meta.Slug, Folder: p.Folder}
+				states, err := installHooks(ctx, g, p.Dir, force)
+				res.Hooks = states
+				if err != nil {
+					res.Error = err.Error()
+					failed++
+				}
+				for _, s := range states {
+					if s.State == hookSkipped {
+						skipped++
+					}
+				}
+				results = append(results, res)
+			}
+
+			if tui.IsJSON() {
+				if results == nil {
+					results = []hooksResult{}
+				}
+				if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+					return err
+				}
+			} else {
+				printHooksResults(cmd.OutOrStdout(), results, "Nothing to install: no git projects matched.")
+				if skipped > 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), tui.Muted("  Existing hooks were kept; re-run with --force to chain them behind the managed hooks."))
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to install hooks in %d project(s)", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "install in every git project")
+	cmd.Flags().StringArrayVar(&where, "where", nil, "filter projects (key=value or key!=value; keys: slug, status, tag, folder)")
+	cmd.Flags().BoolVar(&force, "force", false, "replace hooks projects didn't write (they are kept as <hook>.local and still run)")
+
+	return cmd
+}
+
+func newHooksStatusCmd() *cobra.Command {
+	var (
+		all   bool
+		where []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status [slug]",
+		Short: "Show which projects have missing or outdated hooks",
+		Long: fmt.Sprintf(`Show the state of each managed hook: current (v%d), outdated, missing, or
+foreign (a hook projects didn't write). Without a slug, every git project is
+listed.`, hooks.Version),
+		Example: "  projects hooks status\n  projects hooks status --where folder=work",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			if len(args) == 0 && len(where) == 0 {
+				all = true
+			}
+			projects, err := selectProjects(runtime, args, all, where)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			g := runtime.GitBackend()
+			var results []hooksResult
+			for _, p := range projects {
+				if !g.IsRepo(ctx, p.Dir) {
+					continue
+				}
+				res := hooksResult{Slug: p.Meta.Slug, Folder: p.Folder}
+				states, err := inspectHooks(ctx, g, p.Dir)
+				res.Hooks = states
+				if err != nil {
+					res.Error = err.Error()
+				}
+				results = append(results, res)
+			}
+
+			if tui.IsJSON() {
+				if results == nil {
+					results = []hooksResult{}
+				}
+				return writeJSON(cmd.OutOrStdout(), results)
+			}
+			w := cmd.OutOrStdout()
+			printHooksResults(w, results, "No git projects found.")
+			for _, r := range results {
+				for _, h := range r.Hooks {
+					if h.State != string(hooks.Current) {
+						fmt.Fprintln(w, tui.Muted("  Run 'projects hooks install --all' to bring hooks up to date."))
+						return nil
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "show every git project (the default without a slug)")
+	cmd.Flags().StringArrayVar(&where, "where", nil, "filter projects (key=value or key!=value; keys: slug, status, tag, folder)")
+
+	return cmd
+}
+
+func newHooksRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    "run <hook> [args...]",
+		Short:  "Run a managed hook's checks (called by the hook scripts)",
+		Hidden: true,
+		Args:   cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			// git runs hooks from the top of the work tree.
+			switch args[0] {
+			case "pre-commit":
+				if os.Getenv(preCommitBypassEnv) != "" {
+					return nil
+				}
+				return runPreCommit(cmd.Context(), runtime.GitBackend(), ".", cmd.ErrOrStderr())
+			case "commit-msg":
+				if len(args) < 2 {
+					return fmt.Errorf("commit-msg: missing message file")
+				}
+				msg, err := os.ReadFile(args[1])
+				if err != nil {
+					return err
+				}
+				if err := hooks.CheckMessage(string(msg), runtime.Config.CommitMsgPattern); err != nil {
+					return fmt.Errorf("commit rejected: %w", err)
+				}
+				return nil
+			}
+			return fmt.Errorf("unknown hook %q", args[0])
+		},
+	}
+}
+
+// installHooks installs every managed hook in the repository at dir. A hook
+// that fails doesn't stop the others; the first error is returned.
+func installHooks(ctx context.Context, g git.Git, dir string, force bool) ([]hookState, error) {
+	hooksDir, err := g.HooksDir(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("locate hooks: %w", err)
+	}
+	var (
+		states   []hookState
+		firstErr error
+	)
+	for _, name := range hooks.Names {
+		before, _, err := hooks.Inspect(hooksDir, name)
+		if err == nil {
+			err = hooks.Install(hooksDir, name, force)
+		}
+		action := ""
+		switch {
+		case errors.Is(err, hooks.ErrForeign):
+			action, err = hookSkipped, nil
+		case err != nil:
+			action = "error"
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", name, err)
+			}
+		case before == hooks.Missing:
+			action = hookInstalled
+		case before == hooks.Outdated:
+			action = hookUpdated
+		case before == hooks.Foreign:
+			action = hookReplaced
+		default:
+			action = hookUnchanged
+		}
+		states = append(states, hookState{Name: name, State: action})
+	}
+	return states, firstErr
+}
+
+// inspectHooks reports the state of each managed hook in the repository at dir.
+func inspectHooks(ctx context.Context, g git.Git, dir string) ([]hookState, error) {
+	hooksDir, err := g.HooksDir(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("locate hooks: %w", err)
+	}
+	var states []hookState
+	for _, name := range hooks.Names {
+		state, version, err := hooks.Inspect(hooksDir, name)
+		if err != nil {
+			return states, fmt.Errorf("%s: %w", name, err)
+		}
+		states = append(states, hookState{Name: name, State: string(state), Version: version})
+	}
+	return states, nil
+}
+
+// runPreCommit blocks a commit that stages files under private/ or content
+// that looks like a secret.
+func runPreCommit(ctx context.Context, g git.Git, dir string, log io.Writer) error {
+	files, err := g.StagedFiles(ctx, dir)
+	if err != nil {
+		return fmt.Errorf("list staged files: %w", err)
+	}
+	allow, err := secrets.LoadAllowlist(dir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", secrets.AllowlistFile, err)
+	}
+
+	var findings []prepushFinding
+	for _, name := range files {
+		if isPrivatePath(name) {
+			findings = append(findings, prepushFinding{Path: name, Check: "gitignore", Message: "private file is staged; unstage it with 'git rm --cached'"})
+			continue
+		}
+		var found []secrets.Finding
+		if f, ok := secrets.SensitiveFile(name); ok {
+			found = append(found, f)
+		}
+		data, err := g.StagedContent(ctx, dir, name)
+		if err != nil {
+			return fmt.Errorf("read staged %s: %w", name, err)
+		}
+		if len(data) <= maxScanSize {
+			found = append(found, secrets.ScanFile(name, data)...)
+		}
+		for _, f := range allow.Filter(found) {
+			findings = append(findings, secretFinding(f))
+		}
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	printFindings(log, findings)
+	fmt.Fprintln(log, tui.Muted(fmt.Sprintf("  Move secrets to private/, allowlist false positives in %s, or bypass once with --no-verify.", secrets.AllowlistFile)))
+	return fmt.Errorf("commit blocked: %d problem(s) in staged files", len(findings))
+}
+
+// installProjectHooks installs hooks into a newly created project, reporting
+// problems as warnings.
+func installProjectHooks(ctx context.Context, g git.Git, dir, slug string, log io.Writer) {
+	states, err := installHooks(ctx, g, dir, false)
+	if err != nil {
+		fmt.Fprintln(log, tui.WarningMessage(fmt.Sprintf("install git hooks: %v", err)))
+		return
+	}
+	for _, s := range states {
+		if s.State == hookSkipped {
+			fmt.Fprintln(log, tui.WarningMessage(fmt.Sprintf("kept existing %s hook; run 'projects hooks install %s --force' to manage it", s.Name, slug)))
+		}
+	}
+}
+
+// printHooksResults renders one row per project with a column per hook.
+func printHooksResults(w io.Writer, results []hooksResult, empty string) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, tui.Muted(empty))
+		return
+	}
+	headers := append([]string{"Project"}, hooks.Names...)
+	var rows [][]string
+	for _, r := range results {
+		row := []string{r.Slug}
+		if r.Error != "" && len(r.Hooks) == 0 {
+			for range hooks.Names {
+				row = append(row, "error")
+			}
+			rows = append(rows, row)
+			continue
+		}
+		for _, h := range r.Hooks {
+			label := h.State
+			if h.Version > 0 && h.State != string(hooks.Current) {
+				label += " (v" + strconv.Itoa(h.Version) + ")"
+			}
+			row = append(row, label)
+		}
+		rows = append(rows, row)
+	}
+	fmt.Fprintln(w, tui.Table(headers, rows))
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintln(w, tui.WarningMessage(fmt.Sprintf("%s: %s", r.Slug, r.Error)))
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+)
+
+// gitSetting is one repo-local git config entry.
+type gitSetting struct {
+	Key   string
+	Value string
+}
+
+// identityMismatch is a folder git setting a project's repo doesn't match.
+type identityMismatch struct {
+	Key  string `json:"key"`
+	Want string `json:"want"`
+	Have string `json:"have"`
+}
+
+func (m identityMismatch) String() string {
+	if m.Have == "" {
+		return fmt.Sprintf("%s is unset, folder wants %q", m.Key, m.Want)
+	}
+	return fmt.Sprintf("%s is %q, folder wants %q", m.Key, m.Have, m.Want)
+}
+
+// folderGitSettings returns the repo-local git config a folder asks for: the
+// commit author, commit signing, and the SSH command used for the remote.
+func folderGitSettings(f *config.Folder) []gitSetting {
+	if f == nil {
+		return nil
+	}
+	var settings []gitSetting
+	add := func(key, value string) {
+		if value != "" {
+			settings = append(settings, gitSetting{key, value})
+		}
+	}
+	add("user.name", f.AuthorName)
+	add("user.email", f.AuthorEmail)
+	if f.SigningKey != "" {
+		add("user.signingkey", f.SigningKey)
+		add("commit.gpgsign", "true")
+		if isSSHSigningKey(f.SigningKey) {
+			add("gpg.format", "ssh")
+		}
+	}
+	add("core.sshCommand", f.SSHCommand)
+	return settings
+}
+
+// isSSHSigningKey reports whether a signing key is an SSH key (a public key
+// file, a literal key, or an ssh-agent key::) rather than a GPG key ID.
+func isSSHSigningKey(key string) bool {
+	return strings.HasSuffix(key, ".pub") || strings.HasPrefix(key, "ssh-") || strings.HasPrefix(key, "key::")
+}
+
+// applyFolderIdentity writes folder's git settings into the repository at
+// dir. Settings that previous (the folder the project came from) applied and
+// folder doesn't are removed so they don't leak across folders.
+func applyFolderIdentity(ctx context.Context, g git.Git, dir string, folder, previous *config.Folder) error {
+	want := folderGitSettings(folder)
+	keep := map[string]bool{}
+	for _, s := range want {
+		if err := g.SetLocalConfig(ctx, dir, s.Key, s.Value); err != nil {
+			return fmt.Errorf("set %s: %w", s.Key, err)
+		}
+		keep[s.Key] = true
+	}
+	for _, s := range folderGitSettings(previous) {
+		if keep[s.Key] {
+			continue
+		}
+		if err := g.SetLocalConfig(ctx, dir, s.Key, ""); err != nil {
+			return fmt.Errorf("unset %s: %w", s.Key, err)
+		}
+	}
+	return nil
+}
+
+// identityDrift lists the folder's git settings that the repository at dir
+// doesn't have.
+func identityDrift(ctx context.Context, g git.Git, dir string, folder *config.Folder) ([]identityMismatch, error) {
+	var drift []identityMismatch
+	for _, s := range folderGitSettings(folder) {
+		have, err := g.LocalConfig(ctx, dir, s.Key)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", s.Key, err)
+		}
+		if have != s.Value {
+			drift = append(drift, identityMismatch{Key: s.Key, Want: s.Value, Have: have})
+		}
+	}
+	return drift, nil
+}
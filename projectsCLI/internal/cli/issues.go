@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tasks"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// NewIssuesCmd creates the issues command group.
+func NewIssuesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issues",
+		Short: "Keep tasks/TODO.md and the forge's issues in step",
+	}
+
+	cmd.AddCommand(newIssuesSyncCmd())
+
+	return cmd
+}
+
+func newIssuesSyncCmd() *cobra.Command {
+	var push, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "sync <slug>",
+		Short: "Sync tasks/TODO.md checkboxes with the repo's issues",
+		Long: `Sync the checkbox list in tasks/TODO.md with the hosted repo's issues.
+
+Open issues that aren't in TODO.md yet are added to its Active section as
+"- [ ] <title> (#<number>)". Ticking a linked item closes its issue, and an
+issue closed on the forge ticks its item; reopening either side reopens the
+other. With --push, unticked items without an issue number get an issue
+opened for them and are tagged with its number.
+
+What was linked at the last sync is kept in tasks/.issues.json, so running
+sync twice changes nothing and an issue whose line you deleted from
+TODO.md is not imported again.`,
+		Example: "  projects issues sync my-app\n  projects issues sync my-app --push --dry-run",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			res, err := syncIssues(cmd.Context(), runtime, proj, push, dryRun)
+			if err != nil {
+				return err
+			}
+
+			if tui.IsJSON() {
+				if err := writeJSON(cmd.OutOrStdout(), res); err != nil {
+					return err
+				}
+			} else {
+				printIssuesSync(cmd.OutOrStdout(), res)
+			}
+			if n := res.failed(); n > 0 {
+				return fmt.Errorf("%d of %d issue change(s) failed", n, len(res.Actions))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&push, "push", false, "open issues for unticked items that don't have one")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would change without touching TODO.md or the forge")
+
+	return cmd
+}
+
+// issueAction is one change made (or planned) by issues sync.
+type issueAction struct {
+	Action tasks.ActionKind `json:"action"`
+	Issue  int              `json:"issue,omitempty"`
+	Title  string           `json:"title"`
+	Error  string           `json:"error,omitempty"`
+}
+
+type issuesSyncResult struct {
+	Slug    string        `json:"slug"`
+	Repo    string        `json:"repo"`
+	DryRun  bool          `json:"dry_run,omitempty"`
+	Actions []issueAction `json:"actions"`
+}
+
+func (r issuesSyncResult) failed() int {
+	n := 0
+	for _, a := range r.Actions {
+		if a.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// syncIssues plans and, unless dryRun, applies an issues sync for proj. A
+// failed forge call is recorded on its action and the rest carry on; the
+// failed issue's sync state is left as it was so the next run retries.
+func syncIssues(ctx context.Context, runtime RuntimeContext, proj *project.Project, push, dryRun bool) (issuesSyncResult, error) {
+	res := issuesSyncResult{Slug: proj.Meta.Slug, DryRun: dryRun, Actions: []issueAction{}}
+	p, owner, name, err := projectRemote(ctx, runtime, proj)
+	if err != nil {
+		return res, err
+	}
+	res.Repo = owner + "/" + name
+
+	todoPath := filepath.Join(proj.Dir, "tasks", "TODO.md")
+	data, err := os.ReadFile(todoPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return res, err
+	}
+	f := tasks.Parse(data)
+	statePath := filepath.Join(proj.Dir, filepath.FromSlash(tasks.StateFile))
+	st, err := tasks.LoadState(statePath)
+	if err != nil {
+		return res, fmt.Errorf("read %s: %w", tasks.StateFile, err)
+	}
+
+	issues, err := p.OpenIssues(ctx, owner, name)
+	if err != nil {
+		return res, fmt.Errorf("list issues on %s: %w", res.Repo, err)
+	}
+	open := make(map[int]string, len(issues))
+	for _, issue := range issues {
+		open[issue.Number] = issue.Title
+	}
+
+	plan := tasks.Plan(f, open, st, push)
+	if dryRun {
+		for _, a := range plan {
+			res.Actions = append(res.Actions, issueAction{Action: a.Kind, Issue: a.Issue, Title: a.Title})
+		}
+		return res, nil
+	}
+
+	failed := map[int]bool{}
+	for _, a := range plan {
+		action := issueAction{Action: a.Kind, Issue: a.Issue, Title: a.Title}
+		var err error
+		switch a.Kind {
+		case tasks.Import:
+			f.Add(a.Title, a.Issue)
+		case tasks.Create:
+			var issue *forge.Issue
+			if issue, err = p.CreateIssue(ctx, owner, name, a.Title); err == nil {
+				a.Item.Issue = issue.Number
+				action.Issue = issue.Number
+				open[issue.Number] = issue.Title
+			}
+		case tasks.Close:
+			if err = p.SetIssueClosed(ctx, owner, name, a.Issue, true); err == nil {
+				delete(open, a.Issue)
+			}
+		case tasks.Reopen:
+			if err = p.SetIssueClosed(ctx, owner, name, a.Issue, false); err == nil {
+				open[a.Issue] = a.Title
+			}
+		case tasks.Tick:
+			a.Item.Done = true
+		case tasks.Untick:
+			a.Item.Done = false
+		}
+		if err != nil {
+			action.Error = err.Error()
+			if a.Issue > 0 {
+				failed[a.Issue] = true
+			}
+		}
+		res.Actions = append(res.Actions, action)
+	}
+	if len(plan) == 0 {
+		return res, nil
+	}
+
+	previous := make(map[int]tasks.IssueState, len(failed))
+	for n := range failed {
+		if prev, ok := st.Issues[n]; ok {
+			previous[n] = prev
+		}
+	}
+	st.Record(f, func(n int) bool {
+		_, isOpen := open[n]
+		return !isOpen
+	})
+	for n := range failed {
+		if prev, ok := previous[n]; ok {
+			st.Issues[n] = prev
+		} else {
+			delete(st.Issues, n)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(todoPath), 0755); err != nil {
+		return res, err
+	}
+	if err := os.WriteFile(todoPath, f.Bytes(), 0644); err != nil {
+		return res, fmt.Errorf("write TODO.md: %w", err)
+	}
+	if err := st.Save(statePath); err != nil {
+		return res, fmt.Errorf("write %s: %w", tasks.StateFile, err)
+	}
+	return res, nil
+}
+
+func printIssuesSync(w io.Writer, res issuesSyncResult) {
+	if len(res.Actions) == 0 {
+		fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("TODO.md and %s issues are in sync.", res.Repo)))
+		return
+	}
+	if res.DryRun {
+		fmt.Fprintln(w, tui.InfoMessage(fmt.Sprintf("Would sync TODO.md with %s:", res.Repo)))
+	} else {
+		fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Synced TODO.md with %s.", res.Repo)))
+	}
+	rows := make([][]string, 0, len(res.Actions))
+	for _, a := range res.Actions {
+		issue := "-"
+		if a.Issue > 0 {
+			issue = "#" + strconv.Itoa(a.Issue)
+		}
+		rows = append(rows, []string{string(a.Action), issue, a.Title})
+	}
+	fmt.Fprintln(w, tui.Table([]string{"Action", "Issue", "Title"}, rows))
+	for _, a := range res.Actions {
+		if a.Error != "" {
+			fmt.Fprintln(w, tui.WarningMessage(fmt.Sprintf("%s #%d: %s", a.Action, a.Issue, a.Error)))
+		}
+	}
+}
@@ -2,9 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
 	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
@@ -12,7 +14,10 @@ import (
 
 // NewListCmd lists all projects.
 func NewListCmd() *cobra.Command {
-	var field string
+	var (
+		field         string
+		allWorkspaces bool
+	)
 
 	cmd := &cobra.Command{
 		Use:     "list",
@@ -24,7 +29,13 @@ func NewListCmd() *cobra.Command {
 				return fmt.Errorf("missing runtime context")
 			}
 
-			projects, err := listAllProjects(runtime.Config, runtime.Folder)
+			var projects []*project.Project
+			var err error
+			if allWorkspaces {
+				projects, err = listWorkspaceProjects(runtime.Config.Base(), runtime.Folder)
+			} else {
+				projects, err = listAllProjects(runtime.Config, runtime.Folder)
+			}
 			if err != nil {
 				return err
 			}
@@ -53,60 +64,80 @@ func NewListCmd() *cobra.Command {
 				return nil
 			}
 
-			// If interactive, launch the dashboard TUI.
-			if tui.IsInteractive() {
+			// If interactive, launch the dashboard TUI. Its actions run in the
+			// current workspace, so it's skipped across workspaces.
+			if tui.IsInteractive() && !allWorkspaces {
 				return runDashboard(cmd, projects)
 			}
 
-			// Plain text table — include Folder column if folders are configured.
+			// Plain text table — include Folder column if folders are configured
+			// or any project is external.
 			hasFolders := len(runtime.Config.Folders) > 0
+			for _, p := range projects {
+				hasFolders = hasFolders || p.External || (allWorkspaces && p.Folder != "")
+			}
+			var headers []string
+			if allWorkspaces {
+				headers = append(headers, "Workspace")
+			}
+			headers = append(headers, "Slug")
 			if hasFolders {
-				headers := []string{"Slug", "Folder", "Title", "Status", "Created"}
-				var rows [][]string
-				for _, p := range projects {
-					created := p.Meta.CreatedAt
-					if len(created) > 10 {
-						created = created[:10]
-					}
-					folderDisplay := p.Folder
-					if folderDisplay == "" {
-						folderDisplay = "-"
-					}
-					rows = append(rows, []string{
-						p.Meta.Slug,
-						folderDisplay,
-						p.Meta.Title,
-						p.Meta.Status,
-						created,
-					})
+				headers = append(headers, "Folder")
+			}
+			headers = append(headers, "Title", "Status", "Created")
+
+			var rows [][]string
+			for _, p := range projects {
+				created := p.Meta.CreatedAt
+				if len(created) > 10 {
+					created = created[:10]
 				}
-				fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
-			} else {
-				headers := []string{"Slug", "Title", "Status", "Created"}
-				var rows [][]string
-				for _, p := range projects {
-					created := p.Meta.CreatedAt
-					if len(created) > 10 {
-						created = created[:10]
-					}
-					rows = append(rows, []string{
-						p.Meta.Slug,
-						p.Meta.Title,
-						p.Meta.Status,
-						created,
-					})
+				var row []string
+				if allWorkspaces {
+					row = append(row, p.Workspace)
+				}
+				row = append(row, p.Meta.Slug)
+				if hasFolders {
+					row = append(row, folderLabel(p.Folder, p.External))
 				}
-				fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
+				rows = append(rows, append(row, p.Meta.Title, p.Meta.Status, created))
 			}
+			fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&field, "field", "", "extract specific field from JSON output (e.g. --field dir, --field meta.title)")
+	cmd.Flags().BoolVar(&allWorkspaces, "all-workspaces", false, "list the projects of every workspace")
 
 	return cmd
 }
 
+// listWorkspaceProjects lists the projects of every workspace in cfg, each
+// marked with its workspace. A folderHint only lists workspaces that have
+// that folder.
+func listWorkspaceProjects(cfg config.Config, folderHint string) ([]*project.Project, error) {
+	var all []*project.Project
+	for _, name := range cfg.WorkspaceNames() {
+		wcfg, err := cfg.ForWorkspace(name)
+		if err != nil {
+			return nil, err
+		}
+		if folderHint != "" && wcfg.FolderByName(folderHint) == nil {
+			continue
+		}
+		projects, err := listAllProjects(wcfg, folderHint)
+		if err != nil {
+			return nil, fmt.Errorf("workspace %s: %w", name, err)
+		}
+		for _, p := range projects {
+			p.Workspace = name
+		}
+		all = append(all, projects...)
+	}
+	return all, nil
+}
+
 // runDashboard launches the interactive dashboard TUI and, if a project is
 // selected, shows a command picker and executes the chosen command.
 func runDashboard(cmd *cobra.Command, projects []*project.Project) error {
@@ -126,37 +157,68 @@ func runDashboard(cmd *cobra.Command, projects []*project.Project) error {
 		return nil
 	}
 
-	command, err := pickProjectCommand(slug)
-	if err != nil || command == "" {
+	var selected *project.Project
+	for _, p := range projects {
+		if p.Meta.Slug == slug {
+			selected = p
+			break
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+
+	commandArgs, err := pickProjectCommand(selected)
+	if err != nil || len(commandArgs) == 0 {
 		return err
 	}
 
 	root := cmd.Root()
-	root.SetArgs([]string{command, slug})
+	root.SetArgs(commandArgs)
 	return root.Execute()
 }
 
-// pickProjectCommand shows a command dropdown for the selected project.
-func pickProjectCommand(slug string) (string, error) {
-	type commandOption struct {
-		label   string
-		command string
+// projectCommandOption is an entry in the dashboard's command dropdown; its
+// value is a command name or "run:<script>".
+type projectCommandOption struct {
+	label string
+	value string
+}
+
+// projectCommandOptions lists the dashboard actions for proj: its scripts
+// first — they're usually what you came for — then the built-in commands.
+func projectCommandOptions(proj *project.Project) []projectCommandOption {
+	var options []projectCommandOption
+	for _, name := range proj.Meta.ScriptNames() {
+		options = append(options, projectCommandOption{fmt.Sprintf("Run script: %s", name), "run:" + name})
 	}
+	return append(options,
+		projectCommandOption{"View details", "view"},
+		projectCommandOption{"Edit a file", "edit"},
+		projectCommandOption{"Open in file manager", "open"},
+		projectCommandOption{"Check status", "status"},
+		projectCommandOption{"Push to remote", "push"},
+		projectCommandOption{"Update metadata", "update"},
+		projectCommandOption{"Move to folder", "move"},
+		projectCommandOption{"Delete project", "delete"},
+	)
+}
 
-	commands := []commandOption{
-		{"View details", "view"},
-		{"Edit a file", "edit"},
-		{"Open in file manager", "open"},
-		{"Check status", "status"},
-		{"Push to remote", "push"},
-		{"Update metadata", "update"},
-		{"Move to folder", "move"},
-		{"Delete project", "delete"},
+// projectCommandArgs turns a selected option value into the arguments to run
+// (e.g. ["view", slug] or ["run", slug, "dev"]).
+func projectCommandArgs(slug, selected string) []string {
+	if script, ok := strings.CutPrefix(selected, "run:"); ok {
+		return []string{"run", slug, script}
 	}
+	return []string{selected, slug}
+}
 
-	options := make([]huh.Option[string], len(commands))
-	for i, c := range commands {
-		options[i] = huh.NewOption(c.label, c.command)
+// pickProjectCommand shows a command dropdown for the selected project and
+// returns the arguments to run.
+func pickProjectCommand(proj *project.Project) ([]string, error) {
+	var options []huh.Option[string]
+	for _, o := range projectCommandOptions(proj) {
+		options = append(options, huh.NewOption(o.label, o.value))
 	}
 
 	var selected string
@@ -165,14 +227,14 @@ func pickProjectCommand(slug string) (string, error) {
 	theme.Focused.SelectSelector = theme.Focused.SelectSelector.Foreground(lipgloss.Color(tui.ColorPrimary))
 
 	err := huh.NewSelect[string]().
-		Title(fmt.Sprintf("What would you like to do with %q?", slug)).
+		Title(fmt.Sprintf("What would you like to do with %q?", proj.Meta.Slug)).
 		Options(options...).
 		Value(&selected).
 		WithTheme(theme).
 		Run()
 
-	if err != nil {
-		return "", nil // Ctrl+C / Esc
+	if err != nil || selected == "" {
+		return nil, nil // Ctrl+C / Esc
 	}
-	return selected, nil
+	return projectCommandArgs(proj.Meta.Slug, selected), nil
 }
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/jackmorganxyz/projectsCLI/internal/project"
@@ -12,78 +13,195 @@ import (
 // NewLoadCmd outputs project data for agent consumption.
 func NewLoadCmd() *cobra.Command {
 	var (
-		export bool
-		bash   bool
+		export        bool
+		bash          bool
+		shell         string
+		includeCustom bool
+		withSecrets   bool
+		reveal        bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "load <slug>",
 		Short: "Load project data for agents",
-		Long:  "Output project metadata for agent consumption.\nUse --export for shell variable exports, --bash for eval-able script, or --json for structured data.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Output project metadata for agent consumption.
+
+Use --export for shell export statements, --bash for eval-able bash variables,
+or --json for structured data. --shell picks another dialect: posix (same as
+--export), bash, fish, pwsh, dotenv, or github-actions (for $GITHUB_ENV).
+Values are always quoted so the output is safe to eval or source.
+
+--include-custom adds any extra PROJECT.md frontmatter keys as PROJECT_<KEY>.
+
+--with-secrets merges private/.env into the exports, decrypting it in memory
+if the project is locked with 'projects vault lock'. In JSON output secret
+values are masked unless --reveal is also given.`,
+		Example: `  eval "$(projects load my-app --export)"
+  projects load my-app --shell fish | source
+  projects load my-app --shell github-actions >> "$GITHUB_ENV"`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
 			if !ok {
 				return fmt.Errorf("missing runtime context")
 			}
 
+			dialect, err := loadDialect(export, bash, shell)
+			if err != nil {
+				return err
+			}
+
 			slug := args[0]
 			proj, err := findProject(runtime.Config, slug, runtime.Folder)
 			if err != nil {
 				return err
 			}
 
-			if tui.IsJSON() && !export && !bash {
-				return writeJSON(cmd.OutOrStdout(), proj)
+			if reveal && !withSecrets {
+				return fmt.Errorf("--reveal requires --with-secrets")
 			}
 
-			if bash {
-				return writeBashVars(cmd, proj)
+			var secrets []project.Secret
+			if withSecrets {
+				secrets, err = loadProjectSecrets(proj)
+				if err != nil {
+					return err
+				}
 			}
 
-			if export {
-				return writeExports(cmd, proj)
+			if dialect == "" {
+				// Default: JSON.
+				if !withSecrets {
+					return writeJSON(cmd.OutOrStdout(), proj)
+				}
+				return writeJSON(cmd.OutOrStdout(), loadedProject{
+					Project: proj,
+					Secrets: secretsJSON(secrets, reveal),
+				})
 			}
 
-			// Default: JSON.
-			return writeJSON(cmd.OutOrStdout(), proj)
+			vars := projectEnv(proj)
+			if includeCustom {
+				vars = append(vars, customEnv(proj.Meta.Extra, vars)...)
+			}
+			vars, skipped := appendSecrets(vars, secrets, dialect)
+			for _, key := range skipped {
+				fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("skipping secret %s: not a valid %s variable name", key, dialect)))
+			}
+			return writeEnv(cmd.OutOrStdout(), dialect, vars)
 		},
 	}
 
 	cmd.Flags().BoolVar(&export, "export", false, "output as shell export statements")
 	cmd.Flags().BoolVar(&bash, "bash", false, "output as eval-able bash variables")
+	cmd.Flags().StringVar(&shell, "shell", "", "output dialect: "+strings.Join(shellDialects, ", "))
+	cmd.Flags().BoolVar(&includeCustom, "include-custom", false, "include custom frontmatter keys as PROJECT_<KEY> variables")
+	cmd.Flags().BoolVar(&withSecrets, "with-secrets", false, "merge secrets from private/.env into the output")
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "show secret values in JSON output (masked by default)")
 
 	return cmd
 }
 
-func writeExports(cmd *cobra.Command, proj *project.Project) error {
-	w := cmd.OutOrStdout()
-	fmt.Fprintf(w, "export PROJECT_SLUG=%q\n", proj.Meta.Slug)
-	fmt.Fprintf(w, "export PROJECT_TITLE=%q\n", proj.Meta.Title)
-	fmt.Fprintf(w, "export PROJECT_STATUS=%q\n", proj.Meta.Status)
-	fmt.Fprintf(w, "export PROJECT_DIR=%q\n", proj.Dir)
-	fmt.Fprintf(w, "export PROJECT_DESCRIPTION=%q\n", proj.Meta.Description)
-	if len(proj.Meta.Tags) > 0 {
-		fmt.Fprintf(w, "export PROJECT_TAGS=%q\n", strings.Join(proj.Meta.Tags, ","))
+// loadedProject is the JSON shape of load --with-secrets.
+type loadedProject struct {
+	*project.Project
+	Secrets map[string]string `json:"secrets"`
+}
+
+// maskedSecret replaces secret values in JSON output unless --reveal is set.
+const maskedSecret = "********"
+
+// secretsJSON maps secret keys to their values, or to a mask if !reveal.
+func secretsJSON(secrets []project.Secret, reveal bool) map[string]string {
+	out := make(map[string]string, len(secrets))
+	for _, s := range secrets {
+		if reveal {
+			out[s.Key] = s.Value
+		} else {
+			out[s.Key] = maskedSecret
+		}
 	}
-	if proj.Meta.GitRemote != "" {
-		fmt.Fprintf(w, "export PROJECT_GIT_REMOTE=%q\n", proj.Meta.GitRemote)
+	return out
+}
+
+// shellNameRegexp matches names every shell dialect can assign. Dotenv keys
+// may also contain dots, which only the dotenv dialect can carry.
+var shellNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// appendSecrets adds secrets to vars under their own names. Names already
+// present (the PROJECT_* variables) are not overridden, and names dialect
+// can't express are returned in skipped instead.
+func appendSecrets(vars []envVar, secrets []project.Secret, dialect string) (_ []envVar, skipped []string) {
+	taken := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		taken[v.Name] = true
+	}
+	for _, s := range secrets {
+		if taken[s.Key] {
+			continue
+		}
+		if dialect != "dotenv" && !shellNameRegexp.MatchString(s.Key) {
+			skipped = append(skipped, s.Key)
+			continue
+		}
+		taken[s.Key] = true
+		vars = append(vars, envVar{s.Key, s.Value})
+	}
+	return vars, skipped
+}
+
+// loadDialect resolves --export/--bash/--shell into a single dialect name.
+// An empty result means JSON output.
+func loadDialect(export, bash bool, shell string) (string, error) {
+	set := 0
+	dialect := ""
+	if export {
+		set++
+		dialect = "posix"
+	}
+	if bash {
+		set++
+		dialect = "bash"
 	}
-	return nil
+	if shell != "" {
+		set++
+		dialect = shell
+	}
+	if set > 1 {
+		return "", fmt.Errorf("--export, --bash and --shell are mutually exclusive")
+	}
+	for _, d := range shellDialects {
+		if d == dialect {
+			return dialect, nil
+		}
+	}
+	if dialect != "" {
+		return "", fmt.Errorf("unknown shell %q (supported: %s)", dialect, strings.Join(shellDialects, ", "))
+	}
+	return "", nil
 }
 
-func writeBashVars(cmd *cobra.Command, proj *project.Project) error {
-	w := cmd.OutOrStdout()
-	fmt.Fprintf(w, "PROJECT_SLUG=%q\n", proj.Meta.Slug)
-	fmt.Fprintf(w, "PROJECT_TITLE=%q\n", proj.Meta.Title)
-	fmt.Fprintf(w, "PROJECT_STATUS=%q\n", proj.Meta.Status)
-	fmt.Fprintf(w, "PROJECT_DIR=%q\n", proj.Dir)
-	fmt.Fprintf(w, "PROJECT_DESCRIPTION=%q\n", proj.Meta.Description)
+// envVar is a single NAME=value pair emitted by load or injected into
+// commands run inside a project.
+type envVar struct {
+	Name  string
+	Value string
+}
+
+// projectEnv returns the PROJECT_* variables describing a project.
+func projectEnv(proj *project.Project) []envVar {
+	vars := []envVar{
+		{"PROJECT_SLUG", proj.Meta.Slug},
+		{"PROJECT_TITLE", proj.Meta.Title},
+		{"PROJECT_STATUS", proj.Meta.Status},
+		{"PROJECT_DIR", proj.Dir},
+		{"PROJECT_DESCRIPTION", proj.Meta.Description},
+	}
 	if len(proj.Meta.Tags) > 0 {
-		fmt.Fprintf(w, "PROJECT_TAGS=%q\n", strings.Join(proj.Meta.Tags, ","))
+		vars = append(vars, envVar{"PROJECT_TAGS", strings.Join(proj.Meta.Tags, ",")})
 	}
 	if proj.Meta.GitRemote != "" {
-		fmt.Fprintf(w, "PROJECT_GIT_REMOTE=%q\n", proj.Meta.GitRemote)
+		vars = append(vars, envVar{"PROJECT_GIT_REMOTE", proj.Meta.GitRemote})
 	}
-	return nil
+	return vars
 }
@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
 	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
@@ -12,15 +16,31 @@ import (
 
 // NewMoveCmd moves a project into or out of a folder.
 func NewMoveCmd() *cobra.Command {
-	var folder string
+	var (
+		folder          string
+		transfer, force bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "move <slug>",
 		Short: "Move a project to a different folder",
 		Long: `Move an existing project into a folder, out of a folder, or between folders.
 
-Use --folder <name> to move into a folder. Use --folder "" to move to the top level.`,
-		Args: cobra.ExactArgs(1),
+Use --folder <name> to move into a folder. Use --folder "" to move to the top level.
+
+The destination folder's git author, signing and SSH settings replace the
+source folder's in the project's local git config.
+
+When the destination folder belongs to a different account on the same
+forge, --transfer moves the hosted repo to that account first and points
+origin and PROJECT.md at its new home; an interactive session offers the
+transfer when the flag isn't given. A transfer the new owner still has to
+accept is reported as "pending" and the remote is left unchanged.
+
+External projects (symlinked in, or under links in the config) are left
+where they are unless --force is given; a symlink is moved as a link.`,
+		Example: "  projects move my-app --folder work\n  projects move my-app --folder work --transfer",
+		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
 			if !ok {
@@ -38,6 +58,9 @@ Use --folder <name> to move into a folder. Use --folder "" to move to the top le
 			if err != nil {
 				return err
 			}
+			if proj.External && !force {
+				return fmt.Errorf("project %q is external (%s); pass --force to move it anyway", slug, project.RealDir(proj.Dir))
+			}
 
 			// Determine the destination.
 			var destDir string
@@ -64,6 +87,33 @@ Use --folder <name> to move into a folder. Use --folder "" to move to the top le
 				return fmt.Errorf("destination already exists: %s", destDir)
 			}
 
+			// Transfer the hosted repo before anything moves locally, so a
+			// refused transfer leaves the project as it was.
+			ctx := cmd.Context()
+			g := runtime.GitBackend()
+			var to *config.Folder
+			if folder != "" {
+				to = runtime.Config.FolderByName(folder)
+			}
+			from := folderForProject(runtime.Config, proj)
+			account := folderAccount(runtime.Config, to)
+			// Only offer a transfer transferRemote can carry out.
+			if !cmd.Flags().Changed("transfer") && tui.IsInteractive() && account != "" && sameForge(from, to) && hasProjectRemote(ctx, runtime, proj) {
+				if _, owner, _, err := projectRemote(ctx, runtime, proj); err == nil && !strings.EqualFold(owner, account) {
+					if transfer, err = tui.RunConfirm(fmt.Sprintf("Transfer the remote repo from %s to %s?", owner, account)); err != nil {
+						return err
+					}
+				}
+			}
+			var transferred *forge.Repo
+			var remote *remoteResult
+			if transfer {
+				transferred, remote, err = transferRemote(ctx, runtime, proj, from, to)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Ensure parent directory exists.
 			if err := os.MkdirAll(filepath.Dir(destDir), 0755); err != nil {
 				return fmt.Errorf("create destination directory: %w", err)
@@ -74,8 +124,30 @@ Use --folder <name> to move into a folder. Use --folder "" to move to the top le
 				return fmt.Errorf("move project: %w", err)
 			}
 
+			// Swap the old folder's git identity for the new one's.
+			if g.IsRepo(ctx, destDir) {
+				if err := applyFolderIdentity(ctx, g, destDir, to, from); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("apply folder git identity: %v", err)))
+				}
+			}
+
+			// Point origin and PROJECT.md at the transferred repo.
+			if transferred != nil {
+				if g.IsRepo(ctx, destDir) {
+					old, _ := g.RemoteURL(ctx, destDir)
+					remote.Origin = matchingOrigin(old, transferred)
+					if err := g.SetRemote(ctx, destDir, "origin", remote.Origin); err != nil {
+						return fmt.Errorf("update origin: %w", err)
+					}
+				}
+				proj.Meta.GitRemote = transferred.URL
+				if err := project.WriteProjectFile(destDir, proj.Meta, proj.Body); err != nil {
+					return fmt.Errorf("save remote URL to PROJECT.md: %w", err)
+				}
+			}
+
 			// Regenerate registry.
-			_ = project.WriteRegistry(runtime.Config.ProjectsDir)
+			_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
 
 			if tui.IsJSON() {
 				result := map[string]any{
@@ -90,6 +162,9 @@ Use --folder <name> to move into a folder. Use --folder "" to move to the top le
 				if folder != "" {
 					result["to_folder"] = folder
 				}
+				if remote != nil {
+					result["remote"] = remote
+				}
 				return writeJSON(cmd.OutOrStdout(), result)
 			}
 
@@ -104,12 +179,74 @@ Use --folder <name> to move into a folder. Use --folder "" to move to the top le
 			}
 			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Moved %s from %s to %s", tui.Slug(slug), fromLabel, toLabel)))
 			fmt.Fprintln(w, tui.FormatField("New path", tui.Path(destDir)))
+			switch {
+			case remote == nil:
+			case remote.Action == "pending":
+				fmt.Fprintln(w, tui.WarningMessage(fmt.Sprintf("Transfer of %s to %s is waiting for them to accept; origin is unchanged.", remote.Repo, remote.To)))
+			default:
+				fmt.Fprintln(w, tui.FormatField("Remote", tui.Path(remote.Origin)))
+			}
 			fmt.Fprintln(w, tui.Muted("  "+tui.RandomMoveCheer()))
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&folder, "folder", "", "target folder (empty string for top level)")
+	cmd.Flags().BoolVar(&transfer, "transfer", false, "transfer the hosted repo to the destination folder's account")
+	cmd.Flags().BoolVar(&force, "force", false, "move the project even if it is external")
 
 	return cmd
 }
+
+// transferRemote transfers proj's hosted repo from folder from to folder to's
+// account. Both folders must be on the same forge. The returned repo is nil
+// when the transfer is waiting for the new owner to accept it.
+func transferRemote(ctx context.Context, runtime RuntimeContext, proj *project.Project, from, to *config.Folder) (*forge.Repo, *remoteResult, error) {
+	if _, err := folderForge(from); err != nil {
+		return nil, nil, err
+	}
+	if _, err := folderForge(to); err != nil {
+		return nil, nil, err
+	}
+	if !sameForge(from, to) {
+		return nil, nil, fmt.Errorf("can't transfer a repo between forges; create one in the destination folder with 'projects push' instead")
+	}
+	account := folderAccount(runtime.Config, to)
+	if account == "" {
+		return nil, nil, fmt.Errorf("the destination has no account to transfer to; set github_username or the folder's account")
+	}
+
+	p, owner, name, err := projectRemote(ctx, runtime, proj)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := &remoteResult{Repo: owner + "/" + name, To: account + "/" + name}
+	if strings.EqualFold(owner, account) {
+		return nil, nil, fmt.Errorf("%s already belongs to %s", res.Repo, account)
+	}
+	repo, err := p.TransferRepo(ctx, owner, name, account)
+	if err != nil {
+		return nil, nil, fmt.Errorf("transfer %s to %s: %w", res.Repo, account, err)
+	}
+	if !strings.EqualFold(repo.Owner, account) {
+		res.Action = "pending"
+		return nil, res, nil
+	}
+	res.Action = "transferred"
+	return repo, res, nil
+}
+
+// sameForge reports whether folders from and to host repos on the same
+// forge instance, so one can be transferred between them.
+func sameForge(from, to *config.Folder) bool {
+	fromKind, fromErr := folderForge(from)
+	toKind, toErr := folderForge(to)
+	return fromErr == nil && toErr == nil && fromKind == toKind && folderBaseURL(from) == folderBaseURL(to)
+}
+
+func folderBaseURL(folder *config.Folder) string {
+	if folder == nil {
+		return ""
+	}
+	return strings.TrimSuffix(folder.BaseURL, "/")
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+// defaultJobs is the default worker count for multi-project git operations.
+// They are I/O bound, so this is deliberately higher than a CPU count.
+const defaultJobs = 8
+
+// forEachProject calls fn for each project using at most workers goroutines
+// and waits for them to finish. Once ctx is cancelled no new projects are
+// started; fn is expected to honour ctx for work already in flight.
+func forEachProject(ctx context.Context, projects []*project.Project, workers int, fn func(ctx context.Context, i int, p *project.Project)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(projects); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(ctx, i, projects[i])
+			}
+		}()
+	}
+
+	for i := range projects {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
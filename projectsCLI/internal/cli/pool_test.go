@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+func TestForEachProjectLimitsWorkers(t *testing.T) {
+	projects := make([]*project.Project, 20)
+	for i := range projects {
+		projects[i] = &project.Project{}
+	}
+
+	var running, peak atomic.Int32
+	seen := make([]bool, len(projects))
+	forEachProject(context.Background(), projects, 3, func(ctx context.Context, i int, p *project.Project) {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		seen[i] = true
+		running.Add(-1)
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak.Load())
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("project %d was not visited", i)
+		}
+	}
+}
+
+func TestForEachProjectStopsOnCancel(t *testing.T) {
+	projects := make([]*project.Project, 10)
+	for i := range projects {
+		projects[i] = &project.Project{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	forEachProject(ctx, projects, 1, func(ctx context.Context, i int, p *project.Project) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+	})
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("calls = %d, want 2 after cancel", n)
+	}
+}
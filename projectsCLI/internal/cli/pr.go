@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tasks"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	prCreated = "created"
+	prExists  = "exists"
+)
+
+type prResult struct {
+	Slug    string `json:"slug"`
+	Branch  string `json:"branch"`
+	Base    string `json:"base"`
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Outcome string `json:"outcome"`
+}
+
+// NewPRCmd pushes a project's branch and opens a pull request for it.
+func NewPRCmd() *cobra.Command {
+	var base, title string
+	var draft bool
+
+	cmd := &cobra.Command{
+		Use:   "pr <slug>",
+		Short: "Push the current branch and open a pull request",
+		Long: `Push the project's current branch and open a pull request for it on the
+folder's forge, as the folder's account.
+
+The PR targets the branch 'projects branch' started from (or --base, or
+main). Its title is the commit subject when the branch has a single commit,
+otherwise the branch name; the body lists the branch's commits and the
+tasks/TODO.md items ticked on it, with "Closes #N" for linked issues.
+
+If the branch already has an open PR, its link is printed instead.`,
+		Example: "  projects pr my-app\n  projects pr my-app --draft --title \"Fix login\"",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			res, err := openPullRequest(cmd.Context(), runtime, proj, base, title, draft)
+			if err != nil {
+				return err
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), res)
+			}
+			w := cmd.OutOrStdout()
+			if res.Outcome == prExists {
+				fmt.Fprintln(w, tui.InfoMessage(fmt.Sprintf("%s already has an open pull request: #%d %s", tui.Slug(res.Branch), res.Number, res.Title)))
+			} else {
+				fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Opened #%d %s (%s → %s).", res.Number, res.Title, res.Branch, res.Base)))
+			}
+			fmt.Fprintln(w, "  "+tui.Path(res.URL))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&base, "base", "", "branch to merge into (default: the branch it was started from)")
+	cmd.Flags().StringVar(&title, "title", "", "pull request title (default: generated from the commits)")
+	cmd.Flags().BoolVar(&draft, "draft", false, "open the pull request as a draft")
+
+	return cmd
+}
+
+func openPullRequest(ctx context.Context, runtime RuntimeContext, proj *project.Project, base, title string, draft bool) (prResult, error) {
+	g := runtime.GitBackend()
+	dir := proj.Dir
+	res := prResult{Slug: proj.Meta.Slug}
+
+	if !g.IsRepo(ctx, dir) {
+		return res, fmt.Errorf("%s is not a git repository yet — run 'projects push %s' first", proj.Meta.Slug, proj.Meta.Slug)
+	}
+	branch, err := g.CurrentBranch(ctx, dir)
+	if err != nil {
+		return res, err
+	}
+	if branch == "" {
+		return res, fmt.Errorf("%s has a detached HEAD; check out a branch first", proj.Meta.Slug)
+	}
+	if base == "" {
+		base, _ = g.LocalConfig(ctx, dir, baseBranchKey(branch))
+	}
+	if base == "" {
+		base = "main"
+	}
+	res.Branch, res.Base = branch, base
+	if branch == base {
+		return res, fmt.Errorf("%s is on %s — start a branch first with 'projects branch %s <name>'", proj.Meta.Slug, base, proj.Meta.Slug)
+	}
+
+	commits, err := g.CommitsSince(ctx, dir, base)
+	if err != nil {
+		return res, fmt.Errorf("list commits since %s: %w", base, err)
+	}
+	if len(commits) == 0 {
+		return res, fmt.Errorf("%s has no commits that aren't on %s yet", branch, base)
+	}
+
+	p, owner, name, err := projectRemote(ctx, runtime, proj)
+	if err != nil {
+		return res, err
+	}
+
+	pushCtx := ctx
+	if f := folderForProject(runtime.Config, proj); f != nil && f.GitHubAccount != "" && usesGHCLI(f) {
+		scoped, restore, err := withGHAccount(ctx, runtime.ForgeBackend(), f.GitHubAccount)
+		defer restore()
+		if err != nil {
+			return res, err
+		}
+		pushCtx = scoped
+	}
+	if err := g.PushSetUpstream(pushCtx, dir, "origin", branch); err != nil {
+		return res, fmt.Errorf("git push: %w", err)
+	}
+
+	open, err := p.OpenPullRequests(ctx, owner, name)
+	if err != nil {
+		return res, fmt.Errorf("list pull requests on %s/%s: %w", owner, name, err)
+	}
+	for _, pr := range open {
+		if pr.Head == branch {
+			res.Number, res.Title, res.URL, res.Outcome = pr.Number, pr.Title, pr.URL, prExists
+			return res, nil
+		}
+	}
+
+	if title == "" {
+		title = prTitle(branch, commits)
+	}
+	pr, err := p.CreatePullRequest(ctx, owner, name, forge.PullRequestOptions{
+		Title: title,
+		Body:  prBody(commits, tickedTasks(ctx, g, dir, base)),
+		Head:  branch,
+		Base:  base,
+		Draft: draft,
+	})
+	if err != nil {
+		return res, fmt.Errorf("open pull request on %s/%s: %w", owner, name, err)
+	}
+	res.Number, res.Title, res.URL, res.Outcome = pr.Number, pr.Title, pr.URL, prCreated
+	return res, nil
+}
+
+// prTitle uses the only commit's subject, or else the branch name with its
+// separators turned into spaces.
+func prTitle(branch string, commits []string) string {
+	if len(commits) == 1 {
+		return commits[0]
+	}
+	name := branch[strings.LastIndex(branch, "/")+1:]
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	if name == "" {
+		return branch
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func prBody(commits []string, ticked []*tasks.Item) string {
+	var b strings.Builder
+	b.WriteString("## Commits\n\n")
+	for _, c := range commits {
+		b.WriteString("- " + c + "\n")
+	}
+	if len(ticked) > 0 {
+		b.WriteString("\n## Tasks\n\n")
+		for _, item := range ticked {
+			b.WriteString("- [x] " + item.Text)
+			if item.Issue > 0 {
+				fmt.Fprintf(&b, " (closes #%d)", item.Issue)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// tickedTasks returns the tasks/TODO.md items that are done at HEAD but
+// weren't on base. Items are matched by issue number, or by text when they
+// have none. A TODO.md missing on either side just means no ticked items.
+func tickedTasks(ctx context.Context, g git.Git, dir, base string) []*tasks.Item {
+	const todo = "tasks/TODO.md"
+	head, err := g.ShowFile(ctx, dir, "HEAD", todo)
+	if err != nil {
+		return nil
+	}
+	before, _ := g.ShowFile(ctx, dir, base, todo)
+
+	wasDone := map[string]bool{}
+	for _, item := range tasks.Parse(before).Items {
+		if item.Done {
+			wasDone[taskKey(item)] = true
+		}
+	}
+	var ticked []*tasks.Item
+	for _, item := range tasks.Parse(head).Items {
+		if item.Done && !wasDone[taskKey(item)] {
+			ticked = append(ticked, item)
+		}
+	}
+	return ticked
+}
+
+func taskKey(item *tasks.Item) string {
+	if item.Issue > 0 {
+		return fmt.Sprintf("#%d", item.Issue)
+	}
+	return item.Text
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/secrets"
+	"github.com/jackmorganxyz/projectsCLI/internal/vault"
+)
+
+// defaultMaxFileSize is the largest file push will stage without --force.
+const defaultMaxFileSize = "10MB"
+
+// maxScanSize caps how much of a file is read when looking for secrets.
+const maxScanSize = 2 << 20
+
+// prepushFinding is a problem found in what push is about to stage.
+type prepushFinding struct {
+	Path    string `json:"path"`
+	Check   string `json:"check"` // size, secret or gitignore
+	Message string `json:"message"`
+}
+
+func (f prepushFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Path, f.Message)
+}
+
+// prepushCheck lists what `git add -A` would stage in dir and flags files over
+// maxSize, likely secrets outside private/, and a .gitignore that no longer
+// excludes private/ or an existing private.vault.
+func prepushCheck(ctx context.Context, g git.Git, dir string, maxSize int64) ([]git.StagedChange, []prepushFinding, error) {
+	changes, err := g.StagePreview(ctx, dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("preview changes: %w", err)
+	}
+
+	allow, err := secrets.LoadAllowlist(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", secrets.AllowlistFile, err)
+	}
+
+	var findings []prepushFinding
+	ignored, err := g.IsIgnored(ctx, dir, "private/.env")
+	if err != nil {
+		return nil, nil, fmt.Errorf("check .gitignore: %w", err)
+	}
+	if !ignored {
+		findings = append(findings, prepushFinding{
+			Path:    ".gitignore",
+			Check:   "gitignore",
+			Message: "private/ is no longer excluded; add 'private/' back to .gitignore",
+		})
+	}
+	if vault.IsLocked(dir) {
+		ignored, err := g.IsIgnored(ctx, dir, vault.FileName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("check .gitignore: %w", err)
+		}
+		if !ignored {
+			findings = append(findings, prepushFinding{
+				Path:    ".gitignore",
+				Check:   "gitignore",
+				Message: vault.FileName + " is not excluded; add it to .gitignore",
+			})
+		}
+	}
+
+	for _, c := range changes {
+		if c.Removed {
+			continue
+		}
+		if isPrivatePath(c.Path) {
+			findings = append(findings, prepushFinding{Path: c.Path, Check: "gitignore", Message: "private file would be committed"})
+			continue
+		}
+
+		full := filepath.Join(dir, filepath.FromSlash(c.Path))
+		info, err := os.Stat(full)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if info.Size() > maxSize {
+			findings = append(findings, prepushFinding{
+				Path:    c.Path,
+				Check:   "size",
+				Message: fmt.Sprintf("%s exceeds the %s limit", formatSize(info.Size()), formatSize(maxSize)),
+			})
+		}
+		for _, s := range allow.Filter(scanWorkingFile(dir, c.Path)) {
+			findings = append(findings, secretFinding(s))
+		}
+	}
+	return changes, findings, nil
+}
+
+// parseSize parses a human-readable size such as "10MB", "512KB" or "1G".
+// A bare number is bytes.
+func parseSize(s string) (int64, error) {
+	t := strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(t, u.suffix) {
+			t, mult = strings.TrimSpace(strings.TrimSuffix(t, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(t, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (e.g. 10MB, 512KB)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// formatSize renders a byte count with a binary unit.
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return strconv.FormatFloat(float64(n)/(1<<30), 'f', 1, 64) + "GB"
+	case n >= 1<<20:
+		return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + "MB"
+	case n >= 1<<10:
+		return strconv.FormatFloat(float64(n)/(1<<10), 'f', 1, 64) + "KB"
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
+
+// secretFinding converts a scan finding to a pre-push finding.
+func secretFinding(s secrets.Finding) prepushFinding {
+	f := prepushFinding{Path: s.Path, Check: "secret"}
+	switch {
+	case s.Rule == secrets.SensitiveFileRule:
+		f.Message = strings.ToLower(s.Description[:1]) + s.Description[1:] + "; move it to private/"
+	case s.Commit != "":
+		f.Message = fmt.Sprintf("line %d in commit %s: %s (%s)", s.Line, s.Commit, s.Description, s.Match)
+	default:
+		f.Message = fmt.Sprintf("line %d: %s (%s)", s.Line, s.Description, s.Match)
+	}
+	return f
+}
@@ -1,138 +1,502 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"math"
 
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
 	"github.com/jackmorganxyz/projectsCLI/internal/git"
 	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+// Push outcomes, reported per project by push --all.
+const (
+	pushPushed    = "pushed"
+	pushCommitted = "committed" // --no-github and no remote
+	pushBlocked   = "blocked"
+	pushDryRun    = "dry-run"
+	pushError     = "error"
+)
+
+// pushOptions are the flags shared by single and multi-project push.
+type pushOptions struct {
+	message  string
+	private  bool
+	noGH     bool
+	dryRun   bool
+	force    bool
+	scan     bool
+	syncMeta bool
+	maxSize  int64
+
+	// privateSet is whether --private was given, overriding the folder's
+	// visibility.
+	privateSet bool
+}
+
+// repoPrivate reports whether a repo created for a project in folder should
+// be private: --private if given, else the folder's visibility.
+func (o pushOptions) repoPrivate(folder *config.Folder) bool {
+	if !o.privateSet && folder != nil && folder.Visibility != "" {
+		return folder.Visibility != visibilityPublic
+	}
+	return o.private
+}
+
+// pushResult is the outcome of pushing one project.
+type pushResult struct {
+	Slug     string             `json:"slug"`
+	Folder   string             `json:"folder,omitempty"`
+	Outcome  string             `json:"outcome"`
+	Remote   string             `json:"remote,omitempty"`
+	Files    []git.StagedChange `json:"files,omitempty"`
+	Findings []prepushFinding   `json:"findings,omitempty"`
+	Meta     *metaSyncResult    `json:"meta,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
 // NewPushCmd handles the full git push workflow.
 func NewPushCmd() *cobra.Command {
 	var (
-		message string
-		private bool
-		noGH    bool
+		opts    pushOptions
+		all     bool
+		where   []string
+		maxSize string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "push <slug>",
+		Use:   "push [slug]",
 		Short: "Push project to git remote",
-		Long:  "Stage, commit, and push changes. Creates GitHub repo if no remote exists.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Stage, commit, and push changes. Creates GitHub repo if no remote exists.
+
+Before staging, push checks what 'git add -A' would pick up and refuses if it
+finds files over --max-file-size, anything that looks like a secret outside
+private/, or a .gitignore that no longer excludes private/. --scan also runs
+'projects scan' over tracked files and the commits about to be pushed (all of
+them for a first push). --force turns these into warnings; --dry-run shows
+what would be staged and changes nothing.
+
+--sync-meta then sets the hosted repo's description, topics (from tags) and
+homepage from PROJECT.md, as 'projects remote sync' does.
+
+With --all or --where, every matching git project with uncommitted or
+unpushed work is pushed and a per-project summary is printed.`,
+		Example: "  projects push my-app -m \"Add login\"\n  projects push my-app --dry-run\n  projects push --all\n  projects push --where folder=work",
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
 			if !ok {
 				return fmt.Errorf("missing runtime context")
 			}
+			ctx := cmd.Context()
 
-			slug := args[0]
-			proj, err := findProject(runtime.Config, slug, runtime.Folder)
+			projects, err := selectProjects(runtime, args, all, where)
 			if err != nil {
 				return err
 			}
-			dir := proj.Dir
-
-			// Ensure git is initialized.
-			if !git.IsRepo(dir) {
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.InfoMessage("Setting up git... first commits are special. 🌱"))
-				if err := git.Init(dir); err != nil {
-					return fmt.Errorf("git init: %w", err)
-				}
+			opts.privateSet = cmd.Flags().Changed("private")
+			if opts.maxSize, err = parseSize(maxSize); err != nil {
+				return fmt.Errorf("--max-file-size: %w", err)
 			}
 
-			// Stage and commit.
-			if err := git.AddAll(dir); err != nil {
-				return fmt.Errorf("git add: %w", err)
+			if len(args) == 0 {
+				return pushMany(cmd, runtime, projects, opts)
 			}
 
-			hasChanges, err := git.HasUncommitted(dir)
+			proj := projects[0]
+			res, err := pushProject(ctx, runtime, proj, opts, cmd.ErrOrStderr())
 			if err != nil {
-				return fmt.Errorf("check changes: %w", err)
+				return err
 			}
 
-			if hasChanges {
-				if message == "" {
-					message = "Update project"
+			switch res.Outcome {
+			case pushDryRun:
+				if tui.IsJSON() {
+					return writeJSON(cmd.OutOrStdout(), res)
 				}
-				if err := git.Commit(dir, message); err != nil {
-					return fmt.Errorf("git commit: %w", err)
+				printDryRun(cmd.OutOrStdout(), res)
+				return nil
+			case pushBlocked:
+				if tui.IsJSON() {
+					if err := writeJSON(cmd.OutOrStdout(), res); err != nil {
+						return err
+					}
+				} else {
+					printFindings(cmd.ErrOrStderr(), res.Findings)
 				}
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.SuccessMessage("Changes committed. "+tui.RandomPushCheer()))
-			} else {
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.Muted(tui.RandomNoChanges()))
+				return fmt.Errorf("pre-push checks failed for %q (%d problem(s)); fix them or re-run with --force", res.Slug, len(res.Findings))
 			}
 
-			// Create remote if needed.
-			if !git.HasRemote(dir) && !noGH {
-				if !git.HasGHCLI() {
-					return fmt.Errorf("no remote configured and gh CLI not available; add a remote manually or install gh")
+			if tui.IsJSON() {
+				result := map[string]any{
+					"status": "pushed",
+					"slug":   res.Slug,
+					"remote": res.Remote,
 				}
-
-				// Determine which GitHub account to use.
-				org := runtime.Config.GitHubUsername
-				if f := folderForProject(runtime.Config, proj); f != nil && f.GitHubAccount != "" {
-					// Switch gh auth to the folder's account before creating the repo.
-					fmt.Fprintln(cmd.ErrOrStderr(), tui.InfoMessage(fmt.Sprintf("Switching to GitHub account %s... 🔄", tui.Slug(f.GitHubAccount))))
-					if err := git.SwitchAuth(f.GitHubAccount); err != nil {
-						return fmt.Errorf("could not switch to GitHub account %q — is it authenticated? Run 'gh auth login' to add it: %w", f.GitHubAccount, err)
-					}
-					org = f.GitHubAccount
+				if res.Meta != nil {
+					result["meta"] = res.Meta
 				}
-
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.InfoMessage("Creating GitHub repo... your code deserves a home. 🏠"))
-				repoURL, err := git.CreateRepo(dir, slug, org, private)
-				if err != nil {
-					return fmt.Errorf("create repo: %w", err)
+				if len(res.Findings) > 0 {
+					result["warnings"] = res.Findings
 				}
+				return writeJSON(cmd.OutOrStdout(), result)
+			}
 
-				// Update project metadata with remote URL.
-				proj.Meta.GitRemote = repoURL
-				if err := project.WriteProjectFile(proj.Dir, proj.Meta, proj.Body); err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "warning: failed to save remote URL to PROJECT.md: %v\n", err)
-				}
+			return nil
+		},
+	}
 
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.SuccessMessage(fmt.Sprintf("Repository created: %s", tui.Path(repoURL))))
-			} else if git.HasRemote(dir) {
-				// Switch gh auth if project is in a folder with a GitHub account.
-				if f := folderForProject(runtime.Config, proj); f != nil && f.GitHubAccount != "" {
-					if err := git.SwitchAuth(f.GitHubAccount); err != nil {
-						fmt.Fprintf(cmd.ErrOrStderr(), "warning: could not switch to GitHub account %q — is it authenticated? Run 'gh auth login' to add it\n", f.GitHubAccount)
-					}
-				}
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "commit message")
+	cmd.Flags().BoolVar(&opts.private, "private", true, "create a private repo (default: the folder's visibility, else private)")
+	cmd.Flags().BoolVar(&opts.noGH, "no-github", false, "skip creating a repo on the folder's forge")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "show what would be staged and any check failures, without changing anything")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "push even if pre-push checks fail (failures become warnings)")
+	cmd.Flags().BoolVar(&opts.scan, "scan", false, "also scan tracked files and unpushed commits for secrets")
+	cmd.Flags().BoolVar(&opts.syncMeta, "sync-meta", false, "after pushing, set the repo's description, topics and homepage from PROJECT.md")
+	cmd.Flags().StringVar(&maxSize, "max-file-size", defaultMaxFileSize, "largest file that may be staged (e.g. 10MB, 512KB)")
+	cmd.Flags().BoolVar(&all, "all", false, "push every project with uncommitted or unpushed changes")
+	cmd.Flags().StringArrayVar(&where, "where", nil, "filter projects (key=value or key!=value; keys: slug, status, tag, folder)")
 
-				// Push to existing remote.
-				branch, _ := git.CurrentBranch(dir)
-				if branch == "" {
-					branch = "main"
-				}
-				if err := git.PushSetUpstream(dir, "origin", branch); err != nil {
-					return fmt.Errorf("git push: %w", err)
-				}
-				fmt.Fprintln(cmd.ErrOrStderr(), tui.SuccessMessage("Pushed to remote. "+tui.RandomPushCheer()))
-				if tip := tui.MaybeTip(); tip != "" {
-					fmt.Fprintln(cmd.ErrOrStderr(), tip)
-				}
+	return cmd
+}
+
+// pushMany pushes every selected project that has work to push and prints a
+// summary.
+func pushMany(cmd *cobra.Command, runtime RuntimeContext, selected []*project.Project, opts pushOptions) error {
+	ctx := cmd.Context()
+	g := runtime.GitBackend()
+
+	var projects []*project.Project
+	for _, p := range selected {
+		if !g.IsRepo(ctx, p.Dir) {
+			continue
+		}
+		st, err := g.Inspect(ctx, p.Dir)
+		if err != nil {
+			// Try the push anyway; pushProject reports what fails.
+			projects = append(projects, p)
+			continue
+		}
+		// Commits on a branch with no upstream (or no origin at all) have
+		// never been pushed.
+		unpublished := st.Upstream == "" && !st.Detached && st.LastCommit != nil
+		if st.Dirty() || st.Ahead > 0 || unpublished {
+			projects = append(projects, p)
+		}
+	}
+
+	if len(projects) == 0 {
+		if tui.IsJSON() {
+			return writeJSON(cmd.OutOrStdout(), []pushResult{})
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), tui.Muted(tui.RandomNoChanges()))
+		return nil
+	}
+
+	results := make([]pushResult, 0, len(projects))
+	failed := 0
+	for _, p := range projects {
+		if ctx.Err() != nil {
+			break
+		}
+		res, err := pushProject(ctx, runtime, p, opts, io.Discard)
+		if err != nil {
+			res = pushResult{Slug: p.Meta.Slug, Folder: p.Folder, Outcome: pushError, Error: err.Error()}
+		}
+		if res.Outcome == pushError || res.Outcome == pushBlocked {
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if tui.IsJSON() {
+		if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"Slug", "Result", "Detail"}
+		var rows [][]string
+		for _, r := range results {
+			rows = append(rows, []string{r.Slug, pushOutcomeLabel(r.Outcome), pushDetail(r)})
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
+		for _, r := range results {
+			if len(r.Findings) > 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), tui.Slug(r.Slug))
+				printFindings(cmd.ErrOrStderr(), r.Findings)
 			}
+		}
+	}
 
-			if tui.IsJSON() {
-				remote, _ := git.RemoteURL(dir)
-				return writeJSON(cmd.OutOrStdout(), map[string]any{
-					"status": "pushed",
-					"slug":   slug,
-					"remote": remote,
-				})
+	if ctx.Err() != nil {
+		return fmt.Errorf("interrupted after %d of %d projects", len(results), len(projects))
+	}
+	if failed > 0 {
+		return fmt.Errorf("push failed or was blocked in %d of %d projects", failed, len(results))
+	}
+	return nil
+}
+
+// pushScan runs the secret scan as a push gate: tracked files plus the
+// commits that haven't reached the upstream yet, or the whole history when
+// there is no upstream.
+func pushScan(ctx context.Context, g git.Git, proj *project.Project) ([]prepushFinding, error) {
+	history := 0
+	if g.IsRepo(ctx, proj.Dir) {
+		history = math.MaxInt32
+		if st, err := g.Inspect(ctx, proj.Dir); err == nil && st.Upstream != "" {
+			history = st.Ahead
+		}
+	}
+	found, err := scanProject(ctx, g, proj, history)
+	if err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	findings := make([]prepushFinding, 0, len(found))
+	for _, f := range found {
+		findings = append(findings, secretFinding(f))
+	}
+	return findings, nil
+}
+
+// mergeFindings appends extra to findings, skipping exact duplicates.
+func mergeFindings(findings, extra []prepushFinding) []prepushFinding {
+	seen := map[prepushFinding]bool{}
+	for _, f := range findings {
+		seen[f] = true
+	}
+	for _, f := range extra {
+		if !seen[f] {
+			seen[f] = true
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// pushProject runs the checks and the stage/commit/push workflow for one
+// project, writing progress messages to log. Check failures are reported as
+// a blocked result rather than an error.
+func pushProject(ctx context.Context, runtime RuntimeContext, proj *project.Project, opts pushOptions, log io.Writer) (pushResult, error) {
+	g, forge := runtime.GitBackend(), runtime.ForgeBackend()
+	dir := proj.Dir
+	slug := proj.Meta.Slug
+	res := pushResult{Slug: slug, Folder: proj.Folder}
+
+	changes, findings, err := prepushCheck(ctx, g, dir, opts.maxSize)
+	if err != nil {
+		return res, err
+	}
+	if opts.scan {
+		scanned, err := pushScan(ctx, g, proj)
+		if err != nil {
+			return res, err
+		}
+		findings = mergeFindings(findings, scanned)
+	}
+	res.Findings = findings
+	if opts.dryRun {
+		res.Outcome = pushDryRun
+		res.Files = changes
+		return res, nil
+	}
+	if len(findings) > 0 {
+		if !opts.force {
+			res.Outcome = pushBlocked
+			return res, nil
+		}
+		for _, f := range findings {
+			fmt.Fprintln(log, tui.WarningMessage(f.String()))
+		}
+	}
+
+	// Ensure git is initialized.
+	if !g.IsRepo(ctx, dir) {
+		fmt.Fprintln(log, tui.InfoMessage("Setting up git... first commits are special. 🌱"))
+		if err := g.Init(ctx, dir); err != nil {
+			return res, fmt.Errorf("git init: %w", err)
+		}
+		if err := applyFolderIdentity(ctx, g, dir, folderForProject(runtime.Config, proj), nil); err != nil {
+			fmt.Fprintln(log, tui.WarningMessage(fmt.Sprintf("apply folder git identity: %v", err)))
+		}
+	}
+
+	// Stage and commit.
+	if err := g.AddAll(ctx, dir); err != nil {
+		return res, fmt.Errorf("git add: %w", err)
+	}
+
+	hasChanges, err := g.HasUncommitted(ctx, dir)
+	if err != nil {
+		return res, fmt.Errorf("check changes: %w", err)
+	}
+
+	if hasChanges {
+		message := opts.message
+		if message == "" {
+			message = "Update project"
+		}
+		commitCtx := ctx
+		if opts.force {
+			// The pre-commit hook repeats the checks --force overrode.
+			commitCtx = git.WithEnv(ctx, preCommitBypassEnv+"=1")
+		}
+		if err := g.Commit(commitCtx, dir, message); err != nil {
+			return res, fmt.Errorf("git commit: %w", err)
+		}
+		fmt.Fprintln(log, tui.SuccessMessage("Changes committed. "+tui.RandomPushCheer()))
+	} else {
+		fmt.Fprintln(log, tui.Muted(tui.RandomNoChanges()))
+	}
+
+	res.Outcome = pushPushed
+	folder := folderForProject(runtime.Config, proj)
+	// GitLab and Gitea folders get their repo from the forge's API; the push
+	// below then goes to the new origin.
+	if !g.HasRemote(ctx, dir) && !opts.noGH && !usesGHCLI(folder) {
+		fmt.Fprintln(log, tui.InfoMessage(fmt.Sprintf("Creating %s repo... your code deserves a home. 🏠", folder.Forge)))
+		repo, err := createForgeRepo(ctx, runtime, proj, folder, opts.repoPrivate(folder))
+		if err != nil {
+			return res, err
+		}
+		proj.Meta.GitRemote = repo.URL
+		if err := project.WriteProjectFile(proj.Dir, proj.Meta, proj.Body); err != nil {
+			fmt.Fprintf(log, "warning: failed to save remote URL to PROJECT.md: %v\n", err)
+		}
+		fmt.Fprintln(log, tui.SuccessMessage(fmt.Sprintf("Repository created: %s", tui.Path(repo.URL))))
+	}
+
+	// Create remote if needed.
+	if !g.HasRemote(ctx, dir) && !opts.noGH {
+		if !forge.HasCLI(ctx) {
+			return res, fmt.Errorf("no remote configured and gh CLI not available; add a remote manually or install gh")
+		}
+
+		// Determine which GitHub account to use.
+		org := runtime.Config.GitHubUsername
+		if f := folder; f != nil && f.GitHubAccount != "" {
+			fmt.Fprintln(log, tui.InfoMessage(fmt.Sprintf("Using GitHub account %s... 🔄", tui.Slug(f.GitHubAccount))))
+			scoped, restore, err := withGHAccount(ctx, forge, f.GitHubAccount)
+			defer restore()
+			if err != nil {
+				return res, err
 			}
+			ctx = scoped
+			org = f.GitHubAccount
+		}
 
-			return nil
-		},
+		fmt.Fprintln(log, tui.InfoMessage("Creating GitHub repo... your code deserves a home. 🏠"))
+		repoURL, err := forge.CreateRepo(ctx, dir, slug, org, opts.repoPrivate(folder))
+		if err != nil {
+			return res, fmt.Errorf("create repo: %w", err)
+		}
+
+		// Update project metadata with remote URL.
+		proj.Meta.GitRemote = repoURL
+		if err := project.WriteProjectFile(proj.Dir, proj.Meta, proj.Body); err != nil {
+			fmt.Fprintf(log, "warning: failed to save remote URL to PROJECT.md: %v\n", err)
+		}
+
+		fmt.Fprintln(log, tui.SuccessMessage(fmt.Sprintf("Repository created: %s", tui.Path(repoURL))))
+	} else if g.HasRemote(ctx, dir) {
+		// Push as the folder's GitHub account, if it has one.
+		if f := folder; f != nil && f.GitHubAccount != "" && usesGHCLI(f) {
+			scoped, restore, err := withGHAccount(ctx, forge, f.GitHubAccount)
+			defer restore()
+			if err != nil {
+				fmt.Fprintln(log, tui.WarningMessage(err.Error()))
+			}
+			ctx = scoped
+		}
+
+		// Push to existing remote.
+		branch, _ := g.CurrentBranch(ctx, dir)
+		if branch == "" {
+			branch = "main"
+		}
+		if err := g.PushSetUpstream(ctx, dir, "origin", branch); err != nil {
+			return res, fmt.Errorf("git push: %w", err)
+		}
+		fmt.Fprintln(log, tui.SuccessMessage("Pushed to remote. "+tui.RandomPushCheer()))
+		if tip := tui.MaybeTip(); tip != "" {
+			fmt.Fprintln(log, tip)
+		}
+	} else {
+		res.Outcome = pushCommitted
 	}
 
-	cmd.Flags().StringVarP(&message, "message", "m", "", "commit message")
-	cmd.Flags().BoolVar(&private, "private", true, "create private GitHub repo")
-	cmd.Flags().BoolVar(&noGH, "no-github", false, "skip GitHub repo creation")
+	if opts.syncMeta && res.Outcome == pushPushed {
+		meta, err := syncRemoteMeta(ctx, runtime, proj, metaPush)
+		if err != nil {
+			fmt.Fprintln(log, tui.WarningMessage(fmt.Sprintf("sync repo metadata: %v", err)))
+		} else {
+			res.Meta = &meta
+			if meta.Outcome == metaPushed {
+				fmt.Fprintln(log, tui.SuccessMessage("Repo description, topics and homepage updated from PROJECT.md."))
+			}
+		}
+	}
 
-	return cmd
+	res.Remote, _ = g.RemoteURL(ctx, dir)
+	return res, nil
+}
+
+// printDryRun lists what push would stage and any check failures.
+func printDryRun(w io.Writer, res pushResult) {
+	if len(res.Files) == 0 {
+		fmt.Fprintln(w, tui.Muted(fmt.Sprintf("Nothing to stage in %s.", res.Slug)))
+	} else {
+		fmt.Fprintln(w, tui.InfoMessage(fmt.Sprintf("Would stage %d file(s) in %s:", len(res.Files), tui.Slug(res.Slug))))
+		for _, f := range res.Files {
+			mark := "A"
+			if f.Removed {
+				mark = "D"
+			}
+			fmt.Fprintf(w, "  %s %s\n", mark, f.Path)
+		}
+	}
+	if len(res.Findings) > 0 {
+		fmt.Fprintln(w)
+		printFindings(w, res.Findings)
+	} else {
+		fmt.Fprintln(w, tui.SuccessMessage("Pre-push checks passed"))
+	}
+}
+
+func printFindings(w io.Writer, findings []prepushFinding) {
+	for _, f := range findings {
+		fmt.Fprintln(w, tui.WarningMessage(f.String()))
+	}
+}
+
+func pushOutcomeLabel(outcome string) string {
+	switch outcome {
+	case pushPushed, pushCommitted:
+		return tui.SuccessMessage(outcome)
+	case pushError:
+		return tui.ErrorMessage(outcome)
+	case pushDryRun:
+		return tui.Muted(outcome)
+	default:
+		return tui.WarningMessage(outcome)
+	}
+}
+
+func pushDetail(r pushResult) string {
+	switch r.Outcome {
+	case pushError:
+		return r.Error
+	case pushBlocked:
+		return fmt.Sprintf("%d check failure(s)", len(r.Findings))
+	case pushDryRun:
+		return fmt.Sprintf("%d file(s) to stage, %d check failure(s)", len(r.Files), len(r.Findings))
+	}
+	if len(r.Findings) > 0 {
+		return fmt.Sprintf("%s (forced past %d warning(s))", r.Remote, len(r.Findings))
+	}
+	return r.Remote
 }
@@ -0,0 +1,431 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// NewRemoteCmd creates the remote command group for a project's hosted repo.
+func NewRemoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remote",
+		Short: "Inspect and manage a project's hosted repository",
+		Long: `Inspect and manage the repository a project's origin points at, through
+the API of the forge its folder lives on (GitHub, GitLab or Gitea/Forgejo).
+
+The API token is the folder's token_env, the gh token for its GitHub
+account, or GH_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or FORGEJO_TOKEN.`,
+	}
+
+	cmd.AddCommand(
+		newRemoteInfoCmd(),
+		newRemoteVisibilityCmd(),
+		newRemoteSyncCmd(),
+		newRemoteFixCmd(),
+	)
+
+	return cmd
+}
+
+func newRemoteInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info <slug>",
+		Short: "Show the hosted repository's settings",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			ctx := cmd.Context()
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			p, owner, name, err := projectRemote(ctx, runtime, proj)
+			if err != nil {
+				return err
+			}
+			repo, err := p.GetRepo(ctx, owner, name)
+			if err != nil {
+				return fmt.Errorf("get %s/%s from %s: %w", owner, name, p.Kind(), err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), struct {
+					Forge forge.Kind `json:"forge"`
+					*forge.Repo
+				}{p.Kind(), repo})
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.Header("🌐 "+repo.Owner+"/"+repo.Name))
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, tui.FormatField("Forge", string(p.Kind())))
+			fmt.Fprintln(w, tui.FormatField("Visibility", visibilityLabel(repo.Private)))
+			fmt.Fprintln(w, tui.FormatField("URL", tui.Path(repo.URL)))
+			if repo.Description != "" {
+				fmt.Fprintln(w, tui.FormatField("Description", repo.Description))
+			}
+			if repo.Homepage != "" {
+				fmt.Fprintln(w, tui.FormatField("Homepage", tui.Path(repo.Homepage)))
+			}
+			if len(repo.Topics) > 0 {
+				fmt.Fprintln(w, tui.FormatField("Topics", tui.TagList(repo.Topics)))
+			}
+			fmt.Fprintln(w, tui.FormatField("Clone", repo.CloneURL))
+			fmt.Fprintln(w, tui.FormatField("SSH", repo.SSHURL))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newRemoteVisibilityCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "visibility <slug> public|private",
+		Short:     "Make the hosted repository public or private",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: []string{"public", "private"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			ctx := cmd.Context()
+
+			var private bool
+			switch strings.ToLower(args[1]) {
+			case "public":
+			case "private":
+				private = true
+			default:
+				return fmt.Errorf("visibility must be public or private, not %q", args[1])
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			p, owner, name, err := projectRemote(ctx, runtime, proj)
+			if err != nil {
+				return err
+			}
+			if err := p.SetVisibility(ctx, owner, name, private); err != nil {
+				return fmt.Errorf("set visibility of %s/%s: %w", owner, name, err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"slug":    proj.Meta.Slug,
+					"repo":    owner + "/" + name,
+					"private": private,
+				})
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), tui.SuccessMessage(fmt.Sprintf("%s/%s is now %s.", owner, name, visibilityLabel(private))))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newRemoteSyncCmd() *cobra.Command {
+	var check, pull bool
+
+	cmd := &cobra.Command{
+		Use:   "sync <slug>",
+		Short: "Sync description, tags and homepage between PROJECT.md and the forge",
+		Long: `Compare PROJECT.md's description, homepage and tags with the hosted repo's
+description, homepage and topics, and bring them in line.
+
+By default PROJECT.md wins: fields it sets are pushed to the forge, and
+fields only the forge has are reported but left alone. --pull copies the
+forge's values into PROJECT.md instead, leaving fields only PROJECT.md sets
+alone, and --check only reports the drift, exiting non-zero if there is
+any. Tags become topics in lowercase with hyphens (GitLab has no homepage
+field, so it is not compared there).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			mode := metaPush
+			switch {
+			case check:
+				mode = metaCheck
+			case pull:
+				mode = metaPull
+			}
+			res, err := syncRemoteMeta(cmd.Context(), runtime, proj, mode)
+			if err != nil {
+				return err
+			}
+
+			if tui.IsJSON() {
+				if err := writeJSON(cmd.OutOrStdout(), res); err != nil {
+					return err
+				}
+			} else {
+				printMetaSync(cmd.OutOrStdout(), res)
+			}
+			if check && len(res.Drift) > 0 {
+				return fmt.Errorf("%d field(s) differ between PROJECT.md and %s", len(res.Drift), res.Repo)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "only report drift; exit non-zero if there is any")
+	cmd.Flags().BoolVar(&pull, "pull", false, "copy the forge's values into PROJECT.md")
+	cmd.MarkFlagsMutuallyExclusive("check", "pull")
+
+	return cmd
+}
+
+// Metadata sync modes.
+const (
+	metaCheck = "check"
+	metaPush  = "push"
+	metaPull  = "pull"
+)
+
+// Metadata sync outcomes.
+const (
+	metaInSync = "in-sync"
+	metaDrift  = "drift"
+	metaPushed = "pushed"
+	metaPulled = "pulled"
+)
+
+// Drift directions: which side has a value for the field.
+const (
+	driftLocal  = "local"  // only PROJECT.md
+	driftRemote = "remote" // only the forge
+	driftBoth   = "both"   // both, and they differ
+)
+
+// metaField is one metadata field that differs between PROJECT.md and the
+// forge. Local and Remote are strings, or string lists for topics.
+type metaField struct {
+	Field     string `json:"field"`
+	Local     any    `json:"local"`
+	Remote    any    `json:"remote"`
+	Direction string `json:"direction"`
+}
+
+type metaSyncResult struct {
+	Slug    string      `json:"slug"`
+	Repo    string      `json:"repo"`
+	Outcome string      `json:"outcome"`
+	Drift   []metaField `json:"drift,omitempty"`
+}
+
+// syncRemoteMeta compares proj's metadata with its hosted repo and, unless
+// mode is metaCheck, reconciles them. Drift lists what differed beforehand.
+func syncRemoteMeta(ctx context.Context, runtime RuntimeContext, proj *project.Project, mode string) (metaSyncResult, error) {
+	res := metaSyncResult{Slug: proj.Meta.Slug}
+	p, owner, name, err := projectRemote(ctx, runtime, proj)
+	if err != nil {
+		return res, err
+	}
+	res.Repo = owner + "/" + name
+	repo, err := p.GetRepo(ctx, owner, name)
+	if err != nil {
+		return res, fmt.Errorf("get %s from %s: %w", res.Repo, p.Kind(), err)
+	}
+
+	local := forge.Meta{Description: proj.Meta.Description, Homepage: proj.Meta.Homepage, Topics: forge.Topics(proj.Meta.Tags)}
+	remote := forge.Meta{Description: repo.Description, Homepage: repo.Homepage, Topics: forge.Topics(repo.Topics)}
+	if !p.Kind().HasHomepage() {
+		local.Homepage = ""
+	}
+	res.Drift = metaDiff(local, remote)
+	res.Outcome = metaInSync
+	if len(res.Drift) == 0 {
+		return res, nil
+	}
+	res.Outcome = metaDrift
+
+	switch mode {
+	case metaPush:
+		// Fields only the forge has are kept rather than cleared.
+		want := remote
+		for _, d := range res.Drift {
+			if d.Direction == driftRemote {
+				continue
+			}
+			switch d.Field {
+			case "description":
+				want.Description = local.Description
+			case "homepage":
+				want.Homepage = local.Homepage
+			case "topics":
+				want.Topics = local.Topics
+			}
+		}
+		if metaDiff(want, remote) == nil {
+			return res, nil
+		}
+		if err := p.UpdateRepo(ctx, owner, name, want); err != nil {
+			return res, fmt.Errorf("update %s: %w", res.Repo, err)
+		}
+		res.Outcome = metaPushed
+
+	case metaPull:
+		// Fields only PROJECT.md has are kept rather than cleared.
+		pulled := false
+		for _, d := range res.Drift {
+			if d.Direction == driftLocal {
+				continue
+			}
+			pulled = true
+			switch d.Field {
+			case "description":
+				proj.Meta.Description = repo.Description
+			case "homepage":
+				proj.Meta.Homepage = repo.Homepage
+			case "topics":
+				proj.Meta.Tags = remote.Topics
+			}
+		}
+		if !pulled {
+			return res, nil
+		}
+		proj.Meta.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := project.WriteProjectFile(proj.Dir, proj.Meta, proj.Body); err != nil {
+			return res, fmt.Errorf("write project file: %w", err)
+		}
+		_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
+		res.Outcome = metaPulled
+	}
+	return res, nil
+}
+
+// metaDiff lists the fields that differ between local and remote.
+func metaDiff(local, remote forge.Meta) []metaField {
+	var drift []metaField
+	add := func(field string, l, r any, lset, rset bool) {
+		d := metaField{Field: field, Local: l, Remote: r, Direction: driftBoth}
+		switch {
+		case !rset:
+			d.Direction = driftLocal
+		case !lset:
+			d.Direction = driftRemote
+		}
+		drift = append(drift, d)
+	}
+	if local.Description != remote.Description {
+		add("description", local.Description, remote.Description, local.Description != "", remote.Description != "")
+	}
+	if local.Homepage != remote.Homepage {
+		add("homepage", local.Homepage, remote.Homepage, local.Homepage != "", remote.Homepage != "")
+	}
+	if !slices.Equal(local.Topics, remote.Topics) {
+		add("topics", nonNilTopics(local.Topics), nonNilTopics(remote.Topics), len(local.Topics) > 0, len(remote.Topics) > 0)
+	}
+	return drift
+}
+
+func nonNilTopics(topics []string) []string {
+	if topics == nil {
+		return []string{}
+	}
+	return topics
+}
+
+func printMetaSync(w io.Writer, res metaSyncResult) {
+	switch res.Outcome {
+	case metaInSync:
+		fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("%s matches PROJECT.md.", res.Repo)))
+		return
+	case metaPushed:
+		fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Updated %s from PROJECT.md.", res.Repo)))
+	case metaPulled:
+		fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Updated PROJECT.md from %s.", res.Repo)))
+	default:
+		fmt.Fprintln(w, tui.WarningMessage(fmt.Sprintf("PROJECT.md and %s differ.", res.Repo)))
+	}
+
+	rows := make([][]string, 0, len(res.Drift))
+	for _, d := range res.Drift {
+		rows = append(rows, []string{d.Field, metaValue(d.Local), metaValue(d.Remote)})
+	}
+	fmt.Fprintln(w, tui.Table([]string{"Field", "PROJECT.md", "Forge"}, rows))
+	for _, d := range res.Drift {
+		if res.Outcome == metaPushed && d.Direction == driftRemote {
+			fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  %s is only set on the forge; use --pull to copy it into PROJECT.md", d.Field)))
+		}
+		if res.Outcome == metaPulled && d.Direction == driftLocal {
+			fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  %s is only set in PROJECT.md; run without --pull to push it to the forge", d.Field)))
+		}
+	}
+}
+
+func metaValue(v any) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+// projectRemote resolves proj's origin to a forge provider and repository.
+// The provider comes from the project's folder; origin only names the repo,
+// and must be on the same host so a same-named repo elsewhere isn't touched.
+func projectRemote(ctx context.Context, runtime RuntimeContext, proj *project.Project) (forge.Provider, string, string, error) {
+	remote, err := runtime.GitBackend().RemoteURL(ctx, proj.Dir)
+	if err != nil || remote == "" {
+		remote = proj.Meta.GitRemote
+	}
+	if remote == "" {
+		return nil, "", "", fmt.Errorf("%s has no remote — push it first with 'projects push %s'", proj.Meta.Slug, proj.Meta.Slug)
+	}
+	host, owner, name, err := forge.ParseRepoURL(remote)
+	if err != nil {
+		return nil, "", "", err
+	}
+	p, err := providerFor(ctx, runtime, folderForProject(runtime.Config, proj))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if want := providerHost(p); !strings.EqualFold(host, want) {
+		return nil, "", "", fmt.Errorf("%s's origin is on %s, but its folder uses %s at %s; set the folder's forge and base_url to match", proj.Meta.Slug, host, p.Kind(), want)
+	}
+	return p, owner, name, nil
+}
+
+// providerHost returns the host p serves repository pages from.
+func providerHost(p forge.Provider) string {
+	if u, err := url.Parse(p.RepoURL("owner", "name")); err == nil {
+		return u.Hostname()
+	}
+	return ""
+}
+
+func visibilityLabel(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// Where remote fix takes the truth from.
+const (
+	fixFromOrigin  = "origin"
+	fixFromProject = "project"
+	fixFromForge   = "forge"
+)
+
+// remoteMismatch is a disagreement between PROJECT.md's git_remote, the
+// repo's origin and the folder's account.
+type remoteMismatch struct {
+	Key  string `json:"key"` // git_remote, origin or account
+	Want string `json:"want"`
+	Have string `json:"have"`
+}
+
+func (m remoteMismatch) String() string {
+	switch {
+	case m.Key == "account":
+		return fmt.Sprintf("repo belongs to %s, folder account is %s", m.Have, m.Want)
+	case m.Have == "":
+		return fmt.Sprintf("%s is unset, expected %s", m.Key, m.Want)
+	default:
+		return fmt.Sprintf("%s is %s, expected %s", m.Key, m.Have, m.Want)
+	}
+}
+
+// remoteDrift compares proj's recorded git_remote with its origin, and the
+// repo's owner with the account of the folder the project lives in. Only an
+// owner that is one of the configured accounts counts as account drift; a
+// clone of someone else's repo isn't expected to be owned by the folder. It
+// doesn't call the forge, so a renamed repo shows up only once one side is
+// updated.
+func remoteDrift(ctx context.Context, g git.Git, cfg config.Config, proj *project.Project) []remoteMismatch {
+	var drift []remoteMismatch
+	origin, _ := g.RemoteURL(ctx, proj.Dir)
+	recorded := proj.Meta.GitRemote
+	switch {
+	case origin != "" && recorded == "":
+		web, _ := forge.WebURL(origin)
+		drift = append(drift, remoteMismatch{Key: "git_remote", Want: web})
+	case origin == "" && recorded != "":
+		drift = append(drift, remoteMismatch{Key: "origin", Want: recorded})
+	case origin != "" && !forge.SameRepo(origin, recorded):
+		web, _ := forge.WebURL(origin)
+		drift = append(drift, remoteMismatch{Key: "git_remote", Want: web, Have: recorded})
+	}
+
+	remote := origin
+	if remote == "" {
+		remote = recorded
+	}
+	account := folderAccount(cfg, folderForProject(cfg, proj))
+	if _, owner, _, err := forge.ParseRepoURL(remote); err == nil && account != "" && !ownedBy(owner, account) && configuredOwner(cfg, owner) {
+		drift = append(drift, remoteMismatch{Key: "account", Want: account, Have: owner})
+	}
+	return drift
+}
+
+// configuredOwner reports whether owner is, or is under, the GitHub username
+// or a folder account in cfg.
+func configuredOwner(cfg config.Config, owner string) bool {
+	if cfg.GitHubUsername != "" && ownedBy(owner, cfg.GitHubUsername) {
+		return true
+	}
+	for _, f := range cfg.Folders {
+		if f.GitHubAccount != "" && ownedBy(owner, f.GitHubAccount) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedBy reports whether a repo owner is account or, on GitLab, one of its
+// subgroups.
+func ownedBy(owner, account string) bool {
+	owner, account = strings.ToLower(owner), strings.ToLower(account)
+	return owner == account || strings.HasPrefix(owner, account+"/")
+}
+
+type remoteFixResult struct {
+	Slug      string           `json:"slug"`
+	From      string           `json:"from"`
+	Changed   []remoteMismatch `json:"changed"` // Have is the old value, Want the new
+	Remaining []remoteMismatch `json:"remaining"`
+	Origin    string           `json:"origin,omitempty"`
+	GitRemote string           `json:"git_remote,omitempty"`
+}
+
+func newRemoteFixCmd() *cobra.Command {
+	var from string
+
+	cmd := &cobra.Command{
+		Use:   "fix <slug>",
+		Short: "Reconcile PROJECT.md's git_remote with the repo's origin",
+		Long: `Make PROJECT.md's git_remote and the repo's origin agree again, taking the
+truth from one place:
+
+  origin   (default) record origin's repo in PROJECT.md
+  project  point origin at the repo PROJECT.md records
+  forge    ask the forge where origin's repo lives now, following renames
+           and transfers, and update both
+
+Origin keeps its HTTPS or SSH form. A repo owned by another of the
+configured accounts than the folder's is reported but not moved; use
+'projects move --transfer' or the folder's settings for that.`,
+		Example: "  projects remote fix my-app\n  projects remote fix my-app --from forge",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+			switch from {
+			case fixFromOrigin, fixFromProject, fixFromForge:
+			default:
+				return fmt.Errorf("--from must be %q, %q or %q", fixFromOrigin, fixFromProject, fixFromForge)
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			res, err := fixRemote(cmd.Context(), runtime, proj, from)
+			if err != nil {
+				return err
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), res)
+			}
+			w := cmd.OutOrStdout()
+			if len(res.Changed) == 0 {
+				fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("%s's remote is already consistent.", tui.Slug(res.Slug))))
+			} else {
+				fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Fixed %s's remote from %s.", tui.Slug(res.Slug), res.From)))
+				fmt.Fprintln(w, tui.FormatField("Origin", tui.Path(res.Origin)))
+				fmt.Fprintln(w, tui.FormatField("git_remote", tui.Path(res.GitRemote)))
+			}
+			for _, m := range res.Remaining {
+				fmt.Fprintln(w, tui.WarningMessage(m.String()))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", fixFromOrigin, "where to take the remote from: origin, project or forge")
+
+	return cmd
+}
+
+// fixRemote updates origin and/or PROJECT.md's git_remote from the chosen
+// source and reports what changed and what still disagrees.
+func fixRemote(ctx context.Context, runtime RuntimeContext, proj *project.Project, from string) (remoteFixResult, error) {
+	g := runtime.GitBackend()
+	res := remoteFixResult{Slug: proj.Meta.Slug, From: from, Changed: []remoteMismatch{}}
+	origin, _ := g.RemoteURL(ctx, proj.Dir)
+	recorded := proj.Meta.GitRemote
+
+	newOrigin, newRecorded := origin, recorded
+	switch from {
+	case fixFromOrigin:
+		if origin == "" {
+			return res, fmt.Errorf("%s has no origin to take the remote from; try --from project", proj.Meta.Slug)
+		}
+		web, err := forge.WebURL(origin)
+		if err != nil {
+			return res, err
+		}
+		newRecorded = web
+	case fixFromProject, fixFromForge:
+		source := recorded
+		if from == fixFromForge && origin != "" {
+			source = origin
+		}
+		if source == "" {
+			return res, fmt.Errorf("%s has no remote recorded in PROJECT.md or origin", proj.Meta.Slug)
+		}
+		_, owner, name, err := forge.ParseRepoURL(source)
+		if err != nil {
+			return res, err
+		}
+		p, err := providerFor(ctx, runtime, folderForProject(runtime.Config, proj))
+		if err != nil {
+			return res, err
+		}
+		repo, err := p.GetRepo(ctx, owner, name)
+		if err != nil {
+			return res, fmt.Errorf("get %s/%s from %s: %w", owner, name, p.Kind(), err)
+		}
+		// Leave a value alone when it already names the repo, whatever its form.
+		if !forge.SameRepo(origin, repo.CloneURL) {
+			newOrigin = matchingOrigin(origin, repo)
+		}
+		if from == fixFromForge && !forge.SameRepo(recorded, repo.URL) {
+			newRecorded = repo.URL
+		}
+	}
+
+	if newOrigin != origin {
+		if !g.IsRepo(ctx, proj.Dir) {
+			return res, fmt.Errorf("%s is not a git repository yet — run 'projects push %s' first", proj.Meta.Slug, proj.Meta.Slug)
+		}
+		if err := g.SetRemote(ctx, proj.Dir, "origin", newOrigin); err != nil {
+			return res, fmt.Errorf("update origin: %w", err)
+		}
+		res.Changed = append(res.Changed, remoteMismatch{Key: "origin", Want: newOrigin, Have: origin})
+	}
+	if newRecorded != recorded {
+		proj.Meta.GitRemote = newRecorded
+		if err := project.WriteProjectFile(proj.Dir, proj.Meta, proj.Body); err != nil {
+			return res, fmt.Errorf("save remote URL to PROJECT.md: %w", err)
+		}
+		res.Changed = append(res.Changed, remoteMismatch{Key: "git_remote", Want: newRecorded, Have: recorded})
+	}
+
+	res.Origin, res.GitRemote = newOrigin, newRecorded
+	res.Remaining = remoteDrift(ctx, g, runtime.Config, proj)
+	if res.Remaining == nil {
+		res.Remaining = []remoteMismatch{}
+	}
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// NewRunCmd runs a named script from a project's PROJECT.md frontmatter.
+func NewRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <slug> [script] [-- args...]",
+		Short: "Run a project script",
+		Long: `Run a named script defined in the scripts map of PROJECT.md frontmatter:
+
+  scripts:
+    dev: cd code && npm run dev
+    test: cd code && go test ./... "$@"
+
+Scripts run through the shell from the project root with PROJECT_* variables
+injected. Extra arguments after -- are the script's positional parameters:
+use "$@" (or $1, $2, ...) where they belong, as test does above. Arguments
+given to a script that uses none of them are reported and ignored.
+
+Without a script name, the available scripts are listed.`,
+		Example: "  projects run my-app\n  projects run my-app test -- -run TestLogin",
+		Args:    cobra.MinimumNArgs(1),
+		// main reports errors itself and passes ExitError codes through silently.
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rt, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			slug := args[0]
+			proj, err := findProject(rt.Config, slug, rt.Folder)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				return listScripts(cmd, proj)
+			}
+
+			name := args[1]
+			script, ok := proj.Meta.Scripts[name]
+			if !ok {
+				if len(proj.Meta.Scripts) == 0 {
+					return fmt.Errorf("project %q has no scripts; add a scripts map to PROJECT.md", slug)
+				}
+				return fmt.Errorf("script %q not found in %q (available: %s)", name, slug, strings.Join(proj.Meta.ScriptNames(), ", "))
+			}
+
+			if !tui.IsJSON() {
+				fmt.Fprintln(cmd.ErrOrStderr(), tui.Muted("$ "+script))
+			}
+			if len(args) > 2 && runtime.GOOS != "windows" && !scriptParamRegexp.MatchString(script) {
+				fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(fmt.Sprintf("script %q doesn't use its arguments (no \"$@\" or $1); %s ignored", name, strings.Join(args[2:], " "))))
+			}
+
+			c := scriptCommand(cmd.Context(), proj, script, args[2:]...)
+			c.Stdin = os.Stdin
+			c.Stdout = cmd.OutOrStdout()
+			c.Stderr = cmd.ErrOrStderr()
+			return exitCodeError(c.Run())
+		},
+	}
+
+	return cmd
+}
+
+// scriptParamRegexp matches a shell reference to positional parameters.
+var scriptParamRegexp = regexp.MustCompile(`\$\{?[@*#1-9]`)
+
+// listScripts prints the scripts defined for a project.
+func listScripts(cmd *cobra.Command, proj *project.Project) error {
+	if tui.IsJSON() {
+		scripts := proj.Meta.Scripts
+		if scripts == nil {
+			scripts = map[string]string{}
+		}
+		return writeJSON(cmd.OutOrStdout(), map[string]any{
+			"slug":    proj.Meta.Slug,
+			"scripts": scripts,
+		})
+	}
+
+	w := cmd.OutOrStdout()
+	if len(proj.Meta.Scripts) == 0 {
+		fmt.Fprintln(w, tui.Muted(fmt.Sprintf("No scripts defined for %s. Add a scripts map to PROJECT.md frontmatter.", proj.Meta.Slug)))
+		return nil
+	}
+
+	headers := []string{"Script", "Command"}
+	var rows [][]string
+	for _, name := range proj.Meta.ScriptNames() {
+		rows = append(rows, []string{name, proj.Meta.Scripts[name]})
+	}
+	fmt.Fprintln(w, tui.Table(headers, rows))
+	return nil
+}
+
+// scriptCommand builds a shell invocation of script in the project directory.
+// Extra args become the script's positional parameters ("$@"), so they are
+// never re-parsed by the shell. cmd.exe has no positional parameters; there
+// the args are quoted for cmd and appended to the script.
+func scriptCommand(ctx context.Context, p *project.Project, script string, args ...string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		line := script
+		for _, arg := range args {
+			line += " " + cmdQuote(arg)
+		}
+		c := projectCommand(ctx, p, "cmd", "/S", "/C", line)
+		setCmdLine(c, `cmd /S /C "`+line+`"`)
+		return c
+	}
+	return projectCommand(ctx, p, "sh", append([]string{"-c", script, "sh"}, args...)...)
+}
+
+// cmdQuote quotes arg for cmd.exe when it is empty or has spaces or
+// characters cmd would treat specially.
+func cmdQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"&|<>^()") {
+		return arg
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `""`) + `"`
+}
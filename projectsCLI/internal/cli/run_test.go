@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+func TestScriptCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("scripts run through sh")
+	}
+	p := &project.Project{Dir: t.TempDir(), Meta: project.ProjectMeta{Slug: "demo"}}
+
+	tests := []struct {
+		name   string
+		script string
+		args   []string
+		want   string
+	}{
+		{"no args", `echo hi`, nil, "hi"},
+		{"positional args", `echo "$@"`, []string{"a b", "c"}, "a b c"},
+		{"args are not re-parsed", `printf '%s|' "$@"`, []string{"$HOME", "; echo pwned"}, "$HOME|; echo pwned|"},
+		{"trailing comment", `echo "$1" # say it`, []string{"x"}, "x"},
+		{"unused args", `echo fixed`, []string{"ignored"}, "fixed"},
+		{"project env", `echo "$PROJECT_SLUG"`, nil, "demo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := scriptCommand(context.Background(), p, tt.script, tt.args...)
+			if want := append([]string{"sh", "-c", tt.script, "sh"}, tt.args...); strings.Join(c.Args, "\x00") != strings.Join(want, "\x00") {
+				t.Errorf("args = %q, want %q", c.Args, want)
+			}
+			out, err := c.Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(string(out)); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCmdQuote(t *testing.T) {
+	tests := []struct{ arg, want string }{
+		{"plain", "plain"},
+		{"", `""`},
+		{"a b", `"a b"`},
+		{`say "hi"`, `"say ""hi"""`},
+		{"a&b", `"a&b"`},
+	}
+	for _, tt := range tests {
+		if got := cmdQuote(tt.arg); got != tt.want {
+			t.Errorf("cmdQuote(%q) = %s, want %s", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestProjectCommandOptions(t *testing.T) {
+	p := &project.Project{Meta: project.ProjectMeta{Slug: "demo", Scripts: map[string]string{"test": "go test", "dev": "air"}}}
+	options := projectCommandOptions(p)
+	if len(options) < 3 || options[0].value != "run:dev" || options[1].value != "run:test" || options[2].value != "view" {
+		t.Fatalf("options = %+v", options)
+	}
+	if got := projectCommandArgs("demo", "run:dev"); strings.Join(got, " ") != "run demo dev" {
+		t.Errorf("args for a script = %q", got)
+	}
+	if got := projectCommandArgs("demo", "status"); strings.Join(got, " ") != "status demo" {
+		t.Errorf("args for a command = %q", got)
+	}
+}
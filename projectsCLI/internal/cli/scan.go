@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/secrets"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/jackmorganxyz/projectsCLI/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+// defaultScanHistory is how many commits scan looks back by default.
+const defaultScanHistory = 50
+
+// NewScanCmd scans projects for secrets outside private/.
+func NewScanCmd() *cobra.Command {
+	var (
+		all     bool
+		where   []string
+		history int
+		format  string
+		jobs    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scan [slug]",
+		Short: "Scan projects for secrets outside private/",
+		Long: `Scan a project's tracked and staged files, and the lines added by its last
+--history commits, for secrets that belong in private/: AWS keys, GitHub and
+other API tokens, private key headers, sensitive files such as .env or id_rsa,
+and high-entropy values in .env-like files. private/ itself is skipped.
+Projects without git have their non-ignored files scanned.
+
+Known false positives go in a ` + secrets.AllowlistFile + ` file in the project root, one
+per line: a finding fingerprint, a path glob, or a path glob and rule ID.
+
+Output is a table, JSON (--json or --format json) or SARIF 2.1.0
+(--format sarif). The command fails when anything is found, so it can gate
+CI; 'projects push --scan' runs the same scan before pushing.`,
+		Example: "  projects scan my-app\n  projects scan --all --history 200\n  projects scan --all --format sarif > secrets.sarif",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			if format == "" {
+				format = "text"
+				if tui.IsJSON() {
+					format = "json"
+				}
+			}
+			if format != "text" && format != "json" && format != "sarif" {
+				return fmt.Errorf("unknown --format %q (want text, json or sarif)", format)
+			}
+
+			projects, err := selectProjects(runtime, args, all, where)
+			if err != nil {
+				return err
+			}
+
+			g := runtime.GitBackend()
+			ctx, cancel := context.WithCancel(git.WithNoPrompt(cmd.Context()))
+			defer cancel()
+
+			reports := make([]secrets.Report, len(projects))
+			var scanErr atomic.Value
+			work := func(progress func(string)) error {
+				var done atomic.Int32
+				forEachProject(ctx, projects, jobs, func(ctx context.Context, i int, p *project.Project) {
+					findings, err := scanProject(ctx, g, p, history)
+					if err != nil {
+						scanErr.CompareAndSwap(nil, fmt.Errorf("scan %s: %w", p.Meta.Slug, err))
+					}
+					reports[i] = secrets.Report{Name: p.Meta.Slug, Root: p.Dir, Findings: findings}
+					if progress != nil {
+						progress(fmt.Sprintf("%d/%d", done.Add(1), len(projects)))
+					}
+				})
+				return ctx.Err()
+			}
+			if tui.IsInteractive() && format == "text" {
+				err = tui.RunWithSpinner("Scanning for secrets...", cancel, work)
+			} else {
+				err = work(nil)
+			}
+			if err != nil {
+				return err
+			}
+			if err, _ := scanErr.Load().(error); err != nil {
+				return err
+			}
+
+			total, affected := 0, 0
+			for i := range reports {
+				if reports[i].Findings == nil {
+					reports[i].Findings = []secrets.Finding{}
+				}
+				if n := len(reports[i].Findings); n > 0 {
+					total += n
+					affected++
+				}
+			}
+
+			w := cmd.OutOrStdout()
+			switch format {
+			case "json":
+				err = writeJSON(w, reports)
+			case "sarif":
+				err = secrets.WriteSARIF(w, reports)
+			default:
+				printScanReports(w, reports)
+			}
+			if err != nil {
+				return err
+			}
+
+			if total > 0 {
+				return fmt.Errorf("found %d possible secret(s) in %d project(s)", total, affected)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "scan every project")
+	cmd.Flags().StringArrayVar(&where, "where", nil, "filter projects (key=value or key!=value; keys: slug, status, tag, folder)")
+	cmd.Flags().IntVar(&history, "history", defaultScanHistory, "number of recent commits to scan (0 to skip history)")
+	cmd.Flags().StringVar(&format, "format", "", "output format: text, json or sarif (default text, or json when --json)")
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", defaultJobs, "number of projects to scan concurrently")
+
+	return cmd
+}
+
+// scanProject scans the files git tracks or has staged in a project (or the
+// files it would stage, outside a repository) and the lines added by the last
+// history commits. Allowlisted findings are dropped.
+func scanProject(ctx context.Context, g git.Git, p *project.Project, history int) ([]secrets.Finding, error) {
+	allow, err := secrets.LoadAllowlist(p.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", secrets.AllowlistFile, err)
+	}
+
+	isRepo := g.IsRepo(ctx, p.Dir)
+	var files []string
+	if isRepo {
+		if files, err = g.ListFiles(ctx, p.Dir); err != nil {
+			return nil, err
+		}
+	} else {
+		changes, err := g.StagePreview(ctx, p.Dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range changes {
+			if !c.Removed {
+				files = append(files, c.Path)
+			}
+		}
+	}
+
+	var findings []secrets.Finding
+	for _, name := range files {
+		if isPrivatePath(name) {
+			continue
+		}
+		findings = append(findings, scanWorkingFile(p.Dir, name)...)
+	}
+
+	if isRepo && history > 0 {
+		lines, err := g.AddedLines(ctx, p.Dir, history)
+		if err != nil {
+			return nil, fmt.Errorf("read history: %w", err)
+		}
+		for _, l := range lines {
+			if isPrivatePath(l.Path) {
+				continue
+			}
+			for _, f := range secrets.ScanLine(l.Path, l.Line, l.Text) {
+				f.Commit = shortHash(l.Commit)
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	return allow.Filter(findings), nil
+}
+
+// scanWorkingFile checks one file in the working tree by name and content.
+func scanWorkingFile(dir, name string) []secrets.Finding {
+	var findings []secrets.Finding
+	if f, ok := secrets.SensitiveFile(name); ok {
+		findings = append(findings, f)
+	}
+	full := filepath.Join(dir, filepath.FromSlash(name))
+	info, err := os.Stat(full)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxScanSize {
+		return findings
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return findings
+	}
+	return append(findings, secrets.ScanFile(name, data)...)
+}
+
+func isPrivatePath(p string) bool {
+	return p == "private" || p == vault.FileName || strings.HasPrefix(p, "private/")
+}
+
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
+
+// printScanReports renders findings grouped by project.
+func printScanReports(w io.Writer, reports []secrets.Report) {
+	found := false
+	for _, rep := range reports {
+		if len(rep.Findings) == 0 {
+			continue
+		}
+		found = true
+		fmt.Fprintln(w, tui.Header(rep.Name))
+		headers := []string{"Location", "Rule", "Match", "Fingerprint"}
+		var rows [][]string
+		for _, f := range rep.Findings {
+			loc := f.Path
+			if f.Line > 0 {
+				loc += ":" + strconv.Itoa(f.Line)
+			}
+			if f.Commit != "" {
+				loc += " @" + f.Commit
+			}
+			rows = append(rows, []string{loc, f.Rule, f.Match, f.Fingerprint})
+		}
+		fmt.Fprintln(w, tui.Table(headers, rows))
+		fmt.Fprintln(w)
+	}
+	if !found {
+		fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("No secrets found in %d project(s)", len(reports))))
+		return
+	}
+	fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  Move secrets to private/, or add false positives to %s.", secrets.AllowlistFile)))
+}
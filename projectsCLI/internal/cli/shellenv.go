@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// shellDialects lists the output formats accepted by load --shell.
+var shellDialects = []string{"posix", "bash", "fish", "pwsh", "dotenv", "github-actions"}
+
+// writeEnv writes vars to w in the given dialect. Every dialect quotes values
+// so that eval'ing or sourcing the output never expands or executes them.
+func writeEnv(w io.Writer, dialect string, vars []envVar) error {
+	for _, v := range vars {
+		var line string
+		switch dialect {
+		case "posix":
+			line = "export " + v.Name + "=" + posixQuote(v.Value)
+		case "bash":
+			line = v.Name + "=" + posixQuote(v.Value)
+		case "fish":
+			line = "set -gx " + v.Name + " " + fishQuote(v.Value)
+		case "pwsh":
+			line = "$env:" + v.Name + " = " + pwshQuote(v.Value)
+		case "dotenv":
+			line = v.Name + "=" + dotenvQuote(v.Value)
+		case "github-actions":
+			line = githubEnvLine(v.Name, v.Value)
+		default:
+			return fmt.Errorf("unknown shell %q (supported: %s)", dialect, strings.Join(shellDialects, ", "))
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// posixQuote wraps s in single quotes, the only POSIX quoting with no
+// special characters inside. Embedded single quotes become '\''.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote single-quotes s for fish, where \ and ' are the only escapes.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "'", `\'`)
+	return "'" + s + "'"
+}
+
+// pwshQuote single-quotes s for PowerShell, where '' is a literal quote.
+func pwshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// dotenvQuote quotes s for .env files. Single quotes are literal in the common
+// dotenv parsers; values that can't be single-quoted fall back to double
+// quotes with escapes.
+func dotenvQuote(s string) string {
+	if !strings.ContainsAny(s, "'\n\r") {
+		return "'" + s + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// githubEnvLine formats a variable for $GITHUB_ENV. Multi-line values use the
+// heredoc form with a random delimiter that can't appear in the value.
+func githubEnvLine(name, value string) string {
+	if !strings.ContainsAny(value, "\n\r") {
+		return name + "=" + value
+	}
+	var delim string
+	for {
+		b := make([]byte, 8)
+		_, _ = rand.Read(b)
+		delim = "ghadelimiter_" + hex.EncodeToString(b)
+		if !strings.Contains(value, delim) {
+			break
+		}
+	}
+	return name + "<<" + delim + "\n" + value + "\n" + delim
+}
+
+// customEnv converts custom frontmatter keys into PROJECT_<KEY> variables,
+// sorted by name. Keys that would shadow a built-in variable are skipped.
+func customEnv(extra map[string]any, builtin []envVar) []envVar {
+	taken := make(map[string]bool, len(builtin))
+	for _, v := range builtin {
+		taken[v.Name] = true
+	}
+
+	var vars []envVar
+	for key, value := range extra {
+		name := "PROJECT_" + envName(key)
+		if taken[name] || name == "PROJECT_" {
+			continue
+		}
+		taken[name] = true
+		vars = append(vars, envVar{name, envValue(value)})
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// envName upper-cases key and replaces anything that isn't valid in an
+// environment variable name with an underscore.
+func envName(key string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return strings.Trim(sb.String(), "_")
+}
+
+// envValue renders a frontmatter value as a string: scalars as-is, lists of
+// scalars comma-joined (like PROJECT_TAGS), anything else as JSON.
+func envValue(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			switch item.(type) {
+			case map[string]any, []any:
+				return jsonString(t)
+			}
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		return jsonString(t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func jsonString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+var trickyValues = []string{
+	"plain",
+	"it's quoted",
+	"$(touch /tmp/pwned) `id` $HOME",
+	"café\ttab",
+	"line one\nline two",
+	`back\slash "double"`,
+	"",
+}
+
+func TestWriteEnvPosixRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	for _, dialect := range []string{"posix", "bash"} {
+		for _, value := range trickyValues {
+			var buf bytes.Buffer
+			if err := writeEnv(&buf, dialect, []envVar{{"PROJECT_TEST", value}}); err != nil {
+				t.Fatalf("writeEnv: %v", err)
+			}
+
+			script := buf.String() + `printf '%s' "$PROJECT_TEST"`
+			out, err := exec.Command("sh", "-c", script).Output()
+			if err != nil {
+				t.Fatalf("%s: eval %q: %v", dialect, buf.String(), err)
+			}
+			if string(out) != value {
+				t.Errorf("%s: eval of %q = %q, want %q", dialect, buf.String(), out, value)
+			}
+		}
+	}
+}
+
+func TestWriteEnvDialects(t *testing.T) {
+	tests := []struct {
+		dialect string
+		value   string
+		want    string
+	}{
+		{"posix", "it's", `export V='it'\''s'` + "\n"},
+		{"fish", `it's \ ok`, `set -gx V 'it\'s \\ ok'` + "\n"},
+		{"pwsh", "it's", `$env:V = 'it''s'` + "\n"},
+		{"dotenv", "a $b", `V='a $b'` + "\n"},
+		{"dotenv", "it's\n$x", `V="it's\n\$x"` + "\n"},
+		{"github-actions", "one line", "V=one line\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeEnv(&buf, tt.dialect, []envVar{{"V", tt.value}}); err != nil {
+			t.Fatalf("writeEnv(%s): %v", tt.dialect, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("writeEnv(%s, %q) = %q, want %q", tt.dialect, tt.value, buf.String(), tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeEnv(&buf, "github-actions", []envVar{{"V", "a\nb"}}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "V<<") || lines[3] != strings.TrimPrefix(lines[0], "V<<") {
+		t.Errorf("unexpected github-actions heredoc: %q", buf.String())
+	}
+
+	if err := writeEnv(&buf, "tcsh", []envVar{{"V", "x"}}); err == nil {
+		t.Error("expected error for unknown dialect")
+	}
+}
+
+func TestCustomEnv(t *testing.T) {
+	extra := map[string]any{
+		"owner":      "platform-team",
+		"port":       8080,
+		"stack":      []any{"go", "postgres"},
+		"deploy-env": map[string]any{"region": "eu"},
+		"slug":       "shadowed",
+	}
+	builtin := []envVar{{"PROJECT_SLUG", "demo"}}
+
+	got := map[string]string{}
+	for _, v := range customEnv(extra, builtin) {
+		got[v.Name] = v.Value
+	}
+
+	want := map[string]string{
+		"PROJECT_OWNER":      "platform-team",
+		"PROJECT_PORT":       "8080",
+		"PROJECT_STACK":      "go,postgres",
+		"PROJECT_DEPLOY_ENV": `{"region":"eu"}`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("customEnv = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestAppendSecretsSkipsInvalidNames(t *testing.T) {
+	builtin := []envVar{{"PROJECT_SLUG", "app"}}
+	secrets := []project.Secret{
+		{Key: "PROJECT_SLUG", Value: "ignored"},
+		{Key: "API_KEY", Value: "k"},
+		{Key: "spring.datasource.url", Value: "jdbc:x"},
+	}
+
+	vars, skipped := appendSecrets(builtin, secrets, "posix")
+	if len(vars) != 2 || vars[1] != (envVar{"API_KEY", "k"}) {
+		t.Errorf("posix vars = %v, want PROJECT_SLUG and API_KEY", vars)
+	}
+	if len(skipped) != 1 || skipped[0] != "spring.datasource.url" {
+		t.Errorf("posix skipped = %v, want [spring.datasource.url]", skipped)
+	}
+
+	vars, skipped = appendSecrets(builtin, secrets, "dotenv")
+	if len(vars) != 3 || len(skipped) != 0 {
+		t.Errorf("dotenv = %v skipped %v, want all three names kept", vars, skipped)
+	}
+}
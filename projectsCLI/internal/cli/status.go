@@ -1,9 +1,18 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/forge"
 	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -12,22 +21,63 @@ import (
 type projectHealth struct {
 	Slug         string `json:"slug"`
 	Folder       string `json:"folder,omitempty"`
+	External     bool   `json:"external,omitempty"`
 	Title        string `json:"title"`
 	Status       string `json:"status"`
 	HasGit       bool   `json:"has_git"`
 	HasRemote    bool   `json:"has_remote"`
 	Uncommitted  bool   `json:"uncommitted"`
 	HasProjectMD bool   `json:"has_project_md"`
+
+	Git *git.RepoStatus `json:"git,omitempty"`
+	// IdentityDrift lists folder git settings the repo doesn't have.
+	IdentityDrift []identityMismatch `json:"identity_drift,omitempty"`
+	// RemoteDrift lists disagreements between git_remote, origin and the
+	// folder's account.
+	RemoteDrift []remoteMismatch `json:"remote_drift,omitempty"`
+	// PullRequest is the open PR for a branch started with 'projects branch'.
+	PullRequest *forge.PullRequest `json:"pull_request,omitempty"`
+	// Error joins every check that failed, separated by "; ".
+	Error string `json:"error,omitempty"`
 }
 
+// addError records a failed check without hiding earlier ones.
+func (h *projectHealth) addError(msg string) {
+	if h.Error != "" {
+		h.Error += "; "
+	}
+	h.Error += msg
+}
+
+// staleAfter is how long without a commit before a project is flagged stale.
+const staleAfter = 180 * 24 * time.Hour
+
 // NewStatusCmd shows health check across all projects.
 func NewStatusCmd() *cobra.Command {
-	var field string
+	var (
+		field string
+		jobs  int
+		prs   bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show project health check",
-		Long:  "Display a health summary of all projects including git status and file integrity.",
+		Long: `Display a health summary of all projects including git status and file integrity.
+
+For git repositories this includes the branch, upstream, ahead/behind counts,
+staged/unstaged/untracked/conflicted file counts, stash count and the last
+commit. JSON output carries all of it under "git" (e.g. --field git.ahead).
+
+Projects in a folder with author, signing or SSH settings are flagged when
+their local git config doesn't match ("identity_drift" in JSON).
+
+Projects whose PROJECT.md git_remote, origin and folder account disagree
+are flagged ("remote_drift" in JSON); 'projects remote fix' reconciles them.
+
+With --prs, projects on a branch started with 'projects branch' show the
+link to the branch's open pull request, if it has one ("pull_request" in
+JSON). The lookup asks the forge, so it is off by default.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			runtime, ok := RuntimeFromContext(cmd.Context())
 			if !ok {
@@ -47,24 +97,36 @@ func NewStatusCmd() *cobra.Command {
 				return nil
 			}
 
-			var health []projectHealth
-			for _, p := range projects {
-				h := projectHealth{
-					Slug:         p.Meta.Slug,
-					Folder:       p.Folder,
-					Title:        p.Meta.Title,
-					Status:       p.Meta.Status,
-					HasGit:       git.IsRepo(p.Dir),
-					HasProjectMD: true,
-				}
+			// Nobody can answer a credential prompt in the middle of a parallel run.
+			ctx, cancel := context.WithCancel(git.WithNoPrompt(cmd.Context()))
+			g := runtime.GitBackend()
+			defer cancel()
 
-				if h.HasGit {
-					h.HasRemote = git.HasRemote(p.Dir)
-					uncommitted, _ := git.HasUncommitted(p.Dir)
-					h.Uncommitted = uncommitted
-				}
+			health := make([]projectHealth, len(projects))
+			check := func(progress func(string)) error {
+				var checked atomic.Int32
+				forEachProject(ctx, projects, jobs, func(ctx context.Context, i int, p *project.Project) {
+					health[i] = checkProjectHealth(ctx, g, p, folderForProject(runtime.Config, p))
+					if health[i].HasGit {
+						health[i].RemoteDrift = remoteDrift(ctx, g, runtime.Config, p)
+					}
+					if prs {
+						checkPullRequest(ctx, runtime, p, &health[i])
+					}
+					if progress != nil {
+						progress(fmt.Sprintf("%d/%d", checked.Add(1), len(projects)))
+					}
+				})
+				return ctx.Err()
+			}
 
-				health = append(health, h)
+			if tui.IsInteractive() && !tui.IsJSON() && field == "" {
+				err = tui.RunWithSpinner("Checking projects...", cancel, check)
+			} else {
+				err = check(nil)
+			}
+			if err != nil {
+				return err
 			}
 
 			// Handle --field flag for field extraction
@@ -84,56 +146,149 @@ func NewStatusCmd() *cobra.Command {
 			}
 
 			hasFolders := len(runtime.Config.Folders) > 0
+			for _, h := range health {
+				hasFolders = hasFolders || h.External
+			}
+			headers := []string{"Slug"}
 			if hasFolders {
-				headers := []string{"Slug", "Folder", "Status", "Git", "Remote", "Clean"}
-				var rows [][]string
-				for _, h := range health {
-					folderDisplay := h.Folder
-					if folderDisplay == "" {
-						folderDisplay = "-"
-					}
-					rows = append(rows, []string{
-						h.Slug,
-						folderDisplay,
-						tui.StatusColor(h.Status),
-						gitIcon(h.HasGit),
-						remoteIcon(h.HasGit, h.HasRemote),
-						cleanIcon(h.HasGit, h.Uncommitted),
-					})
+				headers = append(headers, "Folder")
+			}
+			headers = append(headers, "Status", "Branch", "Remote", "Sync", "Changes", "Last commit")
+
+			var rows [][]string
+			for _, h := range health {
+				row := []string{h.Slug}
+				if hasFolders {
+					row = append(row, folderLabel(h.Folder, h.External))
 				}
-				fmt.Fprintln(cmd.OutOrStdout(), tui.Header(tui.RandomStatusHeader()))
-				fmt.Fprintln(cmd.OutOrStdout())
-				fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
-			} else {
-				headers := []string{"Slug", "Status", "Git", "Remote", "Clean"}
-				var rows [][]string
-				for _, h := range health {
-					rows = append(rows, []string{
-						h.Slug,
-						tui.StatusColor(h.Status),
-						gitIcon(h.HasGit),
-						remoteIcon(h.HasGit, h.HasRemote),
-						cleanIcon(h.HasGit, h.Uncommitted),
-					})
+				row = append(row,
+					tui.StatusColor(h.Status),
+					branchLabel(h),
+					remoteIcon(h.HasGit, h.HasRemote),
+					syncLabel(h),
+					changesLabel(h),
+					lastCommitLabel(h),
+				)
+				rows = append(rows, row)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), tui.Header(tui.RandomStatusHeader()))
+			fmt.Fprintln(cmd.OutOrStdout())
+			fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
+
+			drifted := map[string]bool{}
+			for _, h := range health {
+				for _, m := range h.IdentityDrift {
+					fmt.Fprintln(cmd.OutOrStdout(), tui.WarningMessage(fmt.Sprintf("%s: %s", h.Slug, m)))
+					drifted[h.Folder] = true
+				}
+			}
+			for _, f := range runtime.Config.FolderNames() {
+				if drifted[f] {
+					fmt.Fprintln(cmd.OutOrStdout(), tui.Muted(fmt.Sprintf("  Run 'projects folder apply %s' to fix the git identity of its projects.", f)))
+				}
+			}
+			for _, h := range health {
+				for _, m := range h.RemoteDrift {
+					fmt.Fprintln(cmd.OutOrStdout(), tui.WarningMessage(fmt.Sprintf("%s: %s", h.Slug, m)))
+				}
+				if len(h.RemoteDrift) > 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), tui.Muted(fmt.Sprintf("  Run 'projects remote fix %s' to reconcile it.", h.Slug)))
+				}
+			}
+			for _, h := range health {
+				if pr := h.PullRequest; pr != nil {
+					fmt.Fprintln(cmd.OutOrStdout(), tui.InfoMessage(fmt.Sprintf("%s: #%d %s %s", h.Slug, pr.Number, pr.Title, tui.Path(pr.URL))))
 				}
-				fmt.Fprintln(cmd.OutOrStdout(), tui.Header(tui.RandomStatusHeader()))
-				fmt.Fprintln(cmd.OutOrStdout())
-				fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
 			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&field, "field", "", "extract specific field from JSON output (e.g. --field slug, --field status)")
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", defaultJobs, "number of projects to check concurrently")
+	cmd.Flags().BoolVar(&prs, "prs", false, "look up open pull requests for branches started with 'projects branch'")
 
 	return cmd
 }
 
-func gitIcon(hasGit bool) string {
-	if hasGit {
-		return tui.SuccessMessage("yes")
+// checkProjectHealth runs the git checks for a single project, including
+// whether its local git identity matches folder's.
+func checkProjectHealth(ctx context.Context, g git.Git, p *project.Project, folder *config.Folder) projectHealth {
+	h := projectHealth{
+		Slug:         p.Meta.Slug,
+		Folder:       p.Folder,
+		External:     p.External,
+		Title:        p.Meta.Title,
+		Status:       p.Meta.Status,
+		HasGit:       g.IsRepo(ctx, p.Dir),
+		HasProjectMD: true,
+	}
+	// A timed-out rev-parse shouldn't make a repo look like it has no git.
+	if !h.HasGit {
+		if _, err := os.Stat(filepath.Join(p.Dir, ".git")); err == nil {
+			h.HasGit = true
+		}
+	}
+
+	if h.HasGit {
+		h.HasRemote = g.HasRemote(ctx, p.Dir)
+		st, err := g.Inspect(ctx, p.Dir)
+		if err != nil {
+			h.addError(err.Error())
+			return h
+		}
+		h.Git = st
+		h.Uncommitted = st.Dirty()
+
+		drift, err := identityDrift(ctx, g, p.Dir, folder)
+		if err != nil {
+			h.addError(err.Error())
+		}
+		h.IdentityDrift = drift
+	}
+	return h
+}
+
+// checkPullRequest looks up the open pull request for p's branch when the
+// branch was started with 'projects branch'. Other branches are left alone so
+// that status doesn't call the forge for every project.
+func checkPullRequest(ctx context.Context, runtime RuntimeContext, p *project.Project, h *projectHealth) {
+	if h.Git == nil || h.Git.Branch == "" || !h.HasRemote {
+		return
+	}
+	g := runtime.GitBackend()
+	if base, _ := g.LocalConfig(ctx, p.Dir, baseBranchKey(h.Git.Branch)); base == "" {
+		return
+	}
+	provider, owner, name, err := projectRemote(ctx, runtime, p)
+	if err != nil {
+		h.addError(fmt.Sprintf("look up pull request: %v", err))
+		return
+	}
+	open, err := provider.OpenPullRequests(ctx, owner, name)
+	if err != nil {
+		h.addError(fmt.Sprintf("look up pull request: %v", err))
+		return
+	}
+	for _, pr := range open {
+		if pr.Head == h.Git.Branch {
+			h.PullRequest = &pr
+			return
+		}
+	}
+}
+
+func branchLabel(h projectHealth) string {
+	if !h.HasGit {
+		return tui.Muted("no git")
+	}
+	if h.Git == nil {
+		return tui.WarningMessage("error")
 	}
-	return tui.Muted("no")
+	if h.Git.Detached {
+		return tui.WarningMessage("detached")
+	}
+	return h.Git.Branch
 }
 
 func remoteIcon(hasGit, hasRemote bool) string {
@@ -146,12 +301,79 @@ func remoteIcon(hasGit, hasRemote bool) string {
 	return tui.Muted("-")
 }
 
-func cleanIcon(hasGit, uncommitted bool) string {
-	if hasGit && !uncommitted {
-		return tui.SuccessMessage("clean")
+// syncLabel shows ahead/behind counts relative to the upstream branch.
+func syncLabel(h projectHealth) string {
+	if h.Git == nil {
+		return tui.Muted("-")
 	}
-	if hasGit && uncommitted {
-		return tui.WarningMessage("dirty")
+	if h.Git.Upstream == "" {
+		return tui.Muted("no upstream")
+	}
+	if h.Git.Ahead == 0 && h.Git.Behind == 0 {
+		return "="
+	}
+	var parts []string
+	if h.Git.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", h.Git.Ahead))
+	}
+	if h.Git.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", h.Git.Behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// changesLabel summarises working tree changes, e.g. "2 staged, 1 untracked".
+func changesLabel(h projectHealth) string {
+	if h.Git == nil {
+		return tui.Muted("-")
+	}
+	st := h.Git
+	var parts []string
+	for _, c := range []struct {
+		n     int
+		label string
+	}{
+		{st.Conflicted, "conflicted"},
+		{st.Staged, "staged"},
+		{st.Unstaged, "unstaged"},
+		{st.Untracked, "untracked"},
+		{st.Stashes, "stashed"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "clean"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// lastCommitLabel shows how long ago the last commit was, flagging stale projects.
+func lastCommitLabel(h projectHealth) string {
+	if h.Git == nil || h.Git.LastCommit == nil {
+		return tui.Muted("-")
+	}
+	age := time.Since(h.Git.LastCommit.Time)
+	label := formatAge(age) + " ago by " + h.Git.LastCommit.Author
+	if age > staleAfter {
+		return tui.WarningMessage(label)
+	}
+	return label
+}
+
+// formatAge renders a duration as a compact age like "5m", "3d" or "6mo".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo", int(d.Hours()/24/30))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
 	}
-	return tui.Muted("-")
 }
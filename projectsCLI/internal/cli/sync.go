@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// Sync outcomes. Only syncFastForwarded changes the working tree.
+const (
+	syncUpToDate      = "up-to-date"
+	syncFastForwarded = "fast-forwarded"
+	syncAhead         = "ahead"
+	syncDiverged      = "diverged"
+	syncDirty         = "dirty"
+	syncConflict      = "conflict"
+	syncNoUpstream    = "no-upstream"
+	syncError         = "error"
+)
+
+// syncResult is the outcome of syncing one project.
+type syncResult struct {
+	Slug    string `json:"slug"`
+	Folder  string `json:"folder,omitempty"`
+	Outcome string `json:"outcome"`
+	Branch  string `json:"branch,omitempty"`
+	Ahead   int    `json:"ahead"`
+	Behind  int    `json:"behind"`
+	Pulled  int    `json:"pulled"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewSyncCmd fetches projects and fast-forwards them when it is safe.
+func NewSyncCmd() *cobra.Command {
+	var (
+		all   bool
+		where []string
+		jobs  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync [slug]",
+		Short: "Fetch and fast-forward projects with a remote",
+		Long: `Fetch every project that has a remote and fast-forward its current branch
+when that is safe: a clean working tree, an upstream, and no local commits the
+remote doesn't have.
+
+Projects that are diverged, dirty, conflicted or have no upstream are reported
+and left untouched. As with push, each folder's projects are fetched as its
+GitHub account, without changing gh's active account.
+
+Select projects with a slug, --all, or --where key=value filters.`,
+		Example: "  projects sync my-app\n  projects sync --all\n  projects sync --where folder=work",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			selected, err := selectProjects(runtime, args, all, where)
+			if err != nil {
+				return err
+			}
+
+			g, forge := runtime.GitBackend(), runtime.ForgeBackend()
+			ctx, cancel := context.WithCancel(git.WithNoPrompt(cmd.Context()))
+			defer cancel()
+
+			var projects []*project.Project
+			for _, p := range selected {
+				if g.HasRemote(ctx, p.Dir) {
+					projects = append(projects, p)
+				} else if len(args) == 1 {
+					return fmt.Errorf("project %q has no git remote; run 'projects push %s' first", p.Meta.Slug, p.Meta.Slug)
+				}
+			}
+
+			if len(projects) == 0 {
+				if tui.IsJSON() {
+					return writeJSON(cmd.OutOrStdout(), []syncResult{})
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), tui.Muted("No projects with a remote to sync."))
+				return nil
+			}
+
+			results := make([]syncResult, len(projects))
+			work := func(progress func(string)) error {
+				var done atomic.Int32
+				for _, group := range groupByAccount(runtime, projects) {
+					if ctx.Err() != nil {
+						break
+					}
+					scoped, restore, err := withGHAccount(ctx, forge, group.account)
+					if err != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), tui.WarningMessage(err.Error()))
+					}
+					forEachProject(scoped, group.projects, jobs, func(ctx context.Context, _ int, p *project.Project) {
+						results[group.index[p]] = syncProject(ctx, g, p)
+						if progress != nil {
+							progress(fmt.Sprintf("%d/%d", done.Add(1), len(projects)))
+						}
+					})
+					restore()
+				}
+				return ctx.Err()
+			}
+
+			if tui.IsInteractive() && !tui.IsJSON() {
+				err = tui.RunWithSpinner("Syncing projects...", cancel, work)
+			} else {
+				err = work(nil)
+			}
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, r := range results {
+				if r.Outcome == syncError {
+					failed++
+				}
+			}
+
+			if tui.IsJSON() {
+				if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+					return err
+				}
+			} else {
+				headers := []string{"Slug", "Result", "Branch", "Detail"}
+				var rows [][]string
+				for _, r := range results {
+					rows = append(rows, []string{r.Slug, syncOutcomeLabel(r.Outcome), r.Branch, syncDetail(r)})
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), tui.Table(headers, rows))
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("sync failed in %d of %d projects", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "sync every project with a remote")
+	cmd.Flags().StringArrayVar(&where, "where", nil, "filter projects (key=value or key!=value; keys: slug, status, tag, folder)")
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", defaultJobs, "number of projects to sync concurrently")
+
+	return cmd
+}
+
+// accountGroup is a set of projects that push and fetch as the same GitHub
+// account. index maps each project back to its position in the full list.
+type accountGroup struct {
+	account  string
+	projects []*project.Project
+	index    map[*project.Project]int
+}
+
+// groupByAccount splits projects by their folder's GitHub account, in order of
+// first appearance. Each group runs with its account's token, or, on older gh,
+// with that account switched in, so groups run one after another.
+func groupByAccount(runtime RuntimeContext, projects []*project.Project) []*accountGroup {
+	var groups []*accountGroup
+	byAccount := map[string]*accountGroup{}
+	for i, p := range projects {
+		account := ""
+		// Only GitHub accounts map to a gh token.
+		if f := folderForProject(runtime.Config, p); f != nil && usesGHCLI(f) {
+			account = f.GitHubAccount
+		}
+		grp, ok := byAccount[account]
+		if !ok {
+			grp = &accountGroup{account: account, index: map[*project.Project]int{}}
+			byAccount[account] = grp
+			groups = append(groups, grp)
+		}
+		grp.projects = append(grp.projects, p)
+		grp.index[p] = i
+	}
+	return groups
+}
+
+// syncProject fetches one project and fast-forwards it if nothing local
+// would be lost.
+func syncProject(ctx context.Context, g git.Git, p *project.Project) syncResult {
+	r := syncResult{Slug: p.Meta.Slug, Folder: p.Folder}
+	fail := func(err error) syncResult {
+		r.Outcome = syncError
+		r.Error = err.Error()
+		return r
+	}
+
+	if err := g.Fetch(ctx, p.Dir); err != nil {
+		return fail(fmt.Errorf("fetch: %w", err))
+	}
+	st, err := g.Inspect(ctx, p.Dir)
+	if err != nil {
+		return fail(err)
+	}
+	r.Branch, r.Ahead, r.Behind = st.Branch, st.Ahead, st.Behind
+
+	switch {
+	case st.Conflicted > 0:
+		r.Outcome = syncConflict
+	case st.Upstream == "":
+		r.Outcome = syncNoUpstream
+	case st.Ahead > 0 && st.Behind > 0:
+		r.Outcome = syncDiverged
+	case st.Behind == 0 && st.Ahead > 0:
+		r.Outcome = syncAhead
+	case st.Behind == 0:
+		r.Outcome = syncUpToDate
+	case st.Staged+st.Unstaged > 0:
+		// Untracked files are fine: git refuses the merge itself if an
+		// incoming file would overwrite one.
+		r.Outcome = syncDirty
+	default:
+		if err := g.FastForward(ctx, p.Dir); err != nil {
+			return fail(fmt.Errorf("fast-forward: %w", err))
+		}
+		r.Outcome = syncFastForwarded
+		r.Pulled, r.Behind = st.Behind, 0
+	}
+	return r
+}
+
+func syncOutcomeLabel(outcome string) string {
+	switch outcome {
+	case syncUpToDate:
+		return tui.Muted(outcome)
+	case syncFastForwarded, syncAhead:
+		return tui.SuccessMessage(outcome)
+	case syncError, syncConflict:
+		return tui.ErrorMessage(outcome)
+	default:
+		return tui.WarningMessage(outcome)
+	}
+}
+
+func syncDetail(r syncResult) string {
+	switch r.Outcome {
+	case syncFastForwarded:
+		if r.Pulled == 1 {
+			return "pulled 1 commit"
+		}
+		return fmt.Sprintf("pulled %d commits", r.Pulled)
+	case syncAhead:
+		return fmt.Sprintf("↑%d not pushed", r.Ahead)
+	case syncDiverged:
+		return fmt.Sprintf("↑%d ↓%d — merge or rebase by hand", r.Ahead, r.Behind)
+	case syncDirty:
+		return fmt.Sprintf("↓%d waiting; commit or stash first", r.Behind)
+	case syncConflict:
+		return "resolve conflicts first"
+	case syncNoUpstream:
+		return "branch has no upstream"
+	case syncError:
+		return r.Error
+	}
+	return ""
+}
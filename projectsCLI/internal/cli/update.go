@@ -17,12 +17,13 @@ func NewUpdateCmd() *cobra.Command {
 		description string
 		status      string
 		tags        string
+		homepage    string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "update <slug>",
 		Short: "Update project metadata",
-		Long: `Update project metadata including title, description, status, and tags.
+		Long: `Update project metadata including title, description, status, tags and homepage.
 
 Use flags to update specific fields. The updated_at timestamp is automatically set.`,
 		Args: cobra.ExactArgs(1),
@@ -48,6 +49,10 @@ Use flags to update specific fields. The updated_at timestamp is automatically s
 				proj.Meta.Description = description
 				updated = true
 			}
+			if homepage != "" {
+				proj.Meta.Homepage = homepage
+				updated = true
+			}
 			if status != "" {
 				// Validate status
 				if status != "active" && status != "paused" && status != "archived" {
@@ -65,7 +70,7 @@ Use flags to update specific fields. The updated_at timestamp is automatically s
 			}
 
 			if !updated {
-				return fmt.Errorf("no fields to update. Use --title, --description, --status, or --tags")
+				return fmt.Errorf("no fields to update. Use --title, --description, --status, --tags, or --homepage")
 			}
 
 			// Update timestamp
@@ -77,7 +82,7 @@ Use flags to update specific fields. The updated_at timestamp is automatically s
 			}
 
 			// Regenerate registry
-			_ = project.WriteRegistry(runtime.Config.ProjectsDir)
+			_ = project.WriteRegistry(runtime.Config.ProjectsDir, runtime.Config.Links...)
 
 			if tui.IsJSON() {
 				return writeJSON(cmd.OutOrStdout(), map[string]any{
@@ -95,6 +100,9 @@ Use flags to update specific fields. The updated_at timestamp is automatically s
 			if description != "" {
 				fmt.Fprintln(w, tui.FormatField("Description", proj.Meta.Description))
 			}
+			if homepage != "" {
+				fmt.Fprintln(w, tui.FormatField("Homepage", tui.Path(proj.Meta.Homepage)))
+			}
 			if status != "" {
 				fmt.Fprintln(w, tui.FormatField("Status", tui.StatusEmoji(proj.Meta.Status)+tui.StatusColor(proj.Meta.Status)))
 			}
@@ -110,6 +118,7 @@ Use flags to update specific fields. The updated_at timestamp is automatically s
 	cmd.Flags().StringVar(&description, "description", "", "update description")
 	cmd.Flags().StringVar(&status, "status", "", "update status (active/paused/archived)")
 	cmd.Flags().StringVar(&tags, "tags", "", "update tags (comma-separated)")
+	cmd.Flags().StringVar(&homepage, "homepage", "", "update homepage URL")
 
 	return cmd
 }
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/jackmorganxyz/projectsCLI/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+// vaultPassphraseEnv lets scripts and agents supply the vault passphrase
+// without a prompt.
+const vaultPassphraseEnv = "PROJECTS_VAULT_PASSPHRASE"
+
+// NewVaultCmd creates the vault command group for encrypting private/.
+func NewVaultCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vault",
+		Short: "Encrypt a project's private/ directory",
+		Long: `Encrypt private/ into a single private.vault file so secrets are protected
+from backups, sync tools and lost laptops, not just from git.
+
+The key is derived from a passphrase (PBKDF2-SHA256) and the archive is
+sealed with AES-256-GCM. The passphrase is prompted for, or read from
+$PROJECTS_VAULT_PASSPHRASE in non-interactive use.
+
+'projects load <slug> --with-secrets' decrypts a locked vault in memory.`,
+	}
+
+	cmd.AddCommand(
+		newVaultLockCmd(),
+		newVaultUnlockCmd(),
+		newVaultStatusCmd(),
+	)
+
+	return cmd
+}
+
+func newVaultLockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lock <slug>",
+		Short: "Encrypt private/ into private.vault and remove the plaintext",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			if vault.IsLocked(proj.Dir) {
+				return fmt.Errorf("project %q is already locked", proj.Meta.Slug)
+			}
+
+			passphrase, err := vaultPassphrase(true)
+			if err != nil {
+				return err
+			}
+			if err := vault.Lock(proj.Dir, passphrase); err != nil {
+				return fmt.Errorf("lock vault: %w", err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"status": "locked",
+					"slug":   proj.Meta.Slug,
+					"vault":  vault.VaultPath(proj.Dir),
+				})
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Locked %s — %s", tui.Slug(proj.Meta.Slug), tui.RandomVaultLockCheer())))
+			fmt.Fprintln(w, tui.FormatField("Vault", tui.Path(vault.VaultPath(proj.Dir))))
+			fmt.Fprintln(w, tui.Muted("  Don't lose the passphrase — there is no recovery."))
+			return nil
+		},
+	}
+}
+
+func newVaultUnlockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock <slug>",
+		Short: "Decrypt private.vault back into private/",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+			if !vault.IsLocked(proj.Dir) {
+				return fmt.Errorf("project %q is not locked", proj.Meta.Slug)
+			}
+
+			passphrase, err := vaultPassphrase(false)
+			if err != nil {
+				return err
+			}
+			if err := vault.Unlock(proj.Dir, passphrase); err != nil {
+				return fmt.Errorf("unlock vault: %w", err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"status":      "unlocked",
+					"slug":        proj.Meta.Slug,
+					"private_dir": vault.PrivateDir(proj.Dir),
+				})
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Unlocked %s", tui.Slug(proj.Meta.Slug))))
+			fmt.Fprintln(w, tui.FormatField("Private", tui.Path(vault.PrivateDir(proj.Dir))))
+			fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  Run 'projects vault lock %s' when you're done.", proj.Meta.Slug)))
+			return nil
+		},
+	}
+}
+
+func newVaultStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <slug>",
+		Short: "Show whether a project's private/ is locked",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+			if err != nil {
+				return err
+			}
+
+			st := vault.GetStatus(proj.Dir)
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"slug":  proj.Meta.Slug,
+					"vault": st,
+				})
+			}
+
+			w := cmd.OutOrStdout()
+			switch {
+			case st.Locked && st.Plaintext:
+				fmt.Fprintln(w, tui.WarningMessage(fmt.Sprintf("%s is locked, but private/ also has plaintext files", proj.Meta.Slug)))
+			case st.Locked:
+				fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("%s is locked", proj.Meta.Slug)))
+			case st.Plaintext:
+				fmt.Fprintln(w, tui.InfoMessage(fmt.Sprintf("%s is unlocked (plaintext in private/)", proj.Meta.Slug)))
+			default:
+				fmt.Fprintln(w, tui.Muted(fmt.Sprintf("%s has nothing in private/ and no vault", proj.Meta.Slug)))
+			}
+			if st.Locked {
+				fmt.Fprintln(w, tui.FormatField("Vault", tui.Path(st.VaultPath)))
+				fmt.Fprintln(w, tui.FormatField("Locked", st.LockedAt.Format("2006-01-02 15:04")))
+			}
+			return nil
+		},
+	}
+}
+
+// vaultPassphrase reads the passphrase from the environment or prompts for it.
+// When confirm is set (locking), the prompt asks twice.
+func vaultPassphrase(confirm bool) (string, error) {
+	if p := os.Getenv(vaultPassphraseEnv); p != "" {
+		return p, nil
+	}
+	if !tui.IsInteractive() {
+		return "", fmt.Errorf("no passphrase: set $%s in non-interactive mode", vaultPassphraseEnv)
+	}
+
+	theme := huh.ThemeBase()
+	theme.Focused.Title = theme.Focused.Title.Foreground(lipgloss.Color(tui.ColorPrimary))
+
+	var passphrase, again string
+	fields := []huh.Field{
+		huh.NewInput().
+			Title("Vault passphrase").
+			EchoMode(huh.EchoModePassword).
+			Value(&passphrase),
+	}
+	if confirm {
+		fields = append(fields, huh.NewInput().
+			Title("Confirm passphrase").
+			EchoMode(huh.EchoModePassword).
+			Value(&again))
+	}
+
+	if err := huh.NewForm(huh.NewGroup(fields...)).WithTheme(theme).Run(); err != nil {
+		return "", fmt.Errorf("passphrase prompt cancelled")
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	if confirm && passphrase != again {
+		return "", fmt.Errorf("passphrases don't match")
+	}
+	return passphrase, nil
+}
+
+// loadProjectSecrets returns the project's private/.env secrets, decrypting
+// them in memory from private.vault when the project is locked.
+func loadProjectSecrets(proj *project.Project) ([]project.Secret, error) {
+	if _, err := os.Stat(project.SecretsFilePath(proj.Dir)); err == nil || !vault.IsLocked(proj.Dir) {
+		return project.LoadSecrets(proj.Dir)
+	}
+
+	passphrase, err := vaultPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := vault.ReadFile(proj.Dir, passphrase, ".env")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read secrets from vault: %w", err)
+	}
+	return project.ParseDotenv(bytes.NewReader(data))
+}
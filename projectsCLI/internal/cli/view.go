@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
 	"github.com/jackmorganxyz/projectsCLI/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -61,6 +62,9 @@ func NewViewCmd() *cobra.Command {
 			if proj.Meta.Description != "" {
 				fmt.Fprintln(w, tui.FormatField("Description", proj.Meta.Description))
 			}
+			if proj.Meta.Homepage != "" {
+				fmt.Fprintln(w, tui.FormatField("Homepage", tui.Path(proj.Meta.Homepage)))
+			}
 			if len(proj.Meta.Tags) > 0 {
 				fmt.Fprintln(w, tui.FormatField("Tags", tui.TagList(proj.Meta.Tags)))
 			}
@@ -68,6 +72,9 @@ func NewViewCmd() *cobra.Command {
 				fmt.Fprintln(w, tui.FormatField("Remote", tui.Path(proj.Meta.GitRemote)))
 			}
 			fmt.Fprintln(w, tui.FormatField("Directory", tui.Path(proj.Dir)))
+			if proj.External {
+				fmt.Fprintln(w, tui.FormatField("External", tui.Path(project.RealDir(proj.Dir))))
+			}
 
 			if proj.Body != "" {
 				fmt.Fprintln(w)
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+// whereClause is a single --where filter such as "status=active" or "tag!=archived".
+type whereClause struct {
+	Key    string
+	Value  string
+	Negate bool
+}
+
+// whereKeys lists the fields --where can filter on.
+var whereKeys = []string{"slug", "status", "tag", "folder"}
+
+// parseWhere parses --where expressions of the form key=value or key!=value.
+func parseWhere(exprs []string) ([]whereClause, error) {
+	var clauses []whereClause
+	for _, expr := range exprs {
+		var c whereClause
+		if key, value, ok := strings.Cut(expr, "!="); ok {
+			c = whereClause{Key: key, Value: value, Negate: true}
+		} else if key, value, ok := strings.Cut(expr, "="); ok {
+			c = whereClause{Key: key, Value: value}
+		} else {
+			return nil, fmt.Errorf("invalid --where %q: expected key=value or key!=value", expr)
+		}
+
+		c.Key = strings.ToLower(strings.TrimSpace(c.Key))
+		c.Value = strings.TrimSpace(c.Value)
+		if !isWhereKey(c.Key) {
+			return nil, fmt.Errorf("invalid --where key %q (supported: %s)", c.Key, strings.Join(whereKeys, ", "))
+		}
+		if _, err := path.Match(c.Value, ""); err != nil {
+			return nil, fmt.Errorf("invalid --where pattern %q: %w", c.Value, err)
+		}
+		clauses = append(clauses, c)
+	}
+	return clauses, nil
+}
+
+func isWhereKey(key string) bool {
+	for _, k := range whereKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether a project satisfies the clause. Values may be
+// glob patterns (e.g. slug=api-*).
+func (c whereClause) matches(p *project.Project) bool {
+	var candidates []string
+	switch c.Key {
+	case "slug":
+		candidates = []string{p.Meta.Slug}
+	case "status":
+		candidates = []string{p.Meta.Status}
+	case "tag":
+		candidates = p.Meta.Tags
+	case "folder":
+		candidates = []string{p.Folder}
+	}
+
+	found := false
+	for _, v := range candidates {
+		if ok, _ := path.Match(c.Value, v); ok {
+			found = true
+			break
+		}
+	}
+	return found != c.Negate
+}
+
+// filterProjects returns the projects that satisfy every clause.
+func filterProjects(projects []*project.Project, clauses []whereClause) []*project.Project {
+	if len(clauses) == 0 {
+		return projects
+	}
+	var out []*project.Project
+	for _, p := range projects {
+		keep := true
+		for _, c := range clauses {
+			if !c.matches(p) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// selectProjects resolves the usual "[slug] | --all | --where" project
+// selection shared by multi-project commands.
+func selectProjects(runtime RuntimeContext, args []string, all bool, where []string) ([]*project.Project, error) {
+	clauses, err := parseWhere(where)
+	if err != nil {
+		return nil, err
+	}
+	multi := all || len(clauses) > 0
+	if len(args) == 0 && !multi {
+		return nil, fmt.Errorf("specify a project slug, --all, or --where")
+	}
+	if len(args) == 1 && multi {
+		return nil, fmt.Errorf("a slug can't be combined with --all or --where")
+	}
+
+	if len(args) == 1 {
+		proj, err := findProject(runtime.Config, args[0], runtime.Folder)
+		if err != nil {
+			return nil, err
+		}
+		return []*project.Project{proj}, nil
+	}
+	candidates, err := listAllProjects(runtime.Config, runtime.Folder)
+	if err != nil {
+		return nil, err
+	}
+	return filterProjects(candidates, clauses), nil
+}
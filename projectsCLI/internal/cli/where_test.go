@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/project"
+)
+
+func TestParseWhere(t *testing.T) {
+	clauses, err := parseWhere([]string{"status=active", "tag!=archived"})
+	if err != nil {
+		t.Fatalf("parseWhere: %v", err)
+	}
+	if len(clauses) != 2 || clauses[0].Key != "status" || clauses[1].Negate != true {
+		t.Errorf("unexpected clauses: %+v", clauses)
+	}
+
+	for _, bad := range []string{"status", "owner=me", "slug=[bad"} {
+		if _, err := parseWhere([]string{bad}); err == nil {
+			t.Errorf("parseWhere(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestFilterProjects(t *testing.T) {
+	projects := []*project.Project{
+		{Meta: project.ProjectMeta{Slug: "api-server", Status: "active", Tags: []string{"go"}}},
+		{Meta: project.ProjectMeta{Slug: "api-docs", Status: "paused", Tags: []string{"docs"}}, Folder: "work"},
+		{Meta: project.ProjectMeta{Slug: "blog", Status: "active"}},
+	}
+
+	tests := []struct {
+		where []string
+		want  []string
+	}{
+		{nil, []string{"api-server", "api-docs", "blog"}},
+		{[]string{"slug=api-*"}, []string{"api-server", "api-docs"}},
+		{[]string{"status=active", "tag=go"}, []string{"api-server"}},
+		{[]string{"tag!=go"}, []string{"api-docs", "blog"}},
+		{[]string{"folder=work"}, []string{"api-docs"}},
+		{[]string{"folder="}, []string{"api-server", "blog"}},
+	}
+
+	for _, tt := range tests {
+		clauses, err := parseWhere(tt.where)
+		if err != nil {
+			t.Fatalf("parseWhere(%v): %v", tt.where, err)
+		}
+		var got []string
+		for _, p := range filterProjects(projects, clauses) {
+			got = append(got, p.Meta.Slug)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("filter %v = %v, want %v", tt.where, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("filter %v = %v, want %v", tt.where, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/config"
+	"github.com/jackmorganxyz/projectsCLI/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceCmd creates the workspace command group for switching between
+// projects trees.
+func NewWorkspaceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workspace",
+		Aliases: []string{"ws"},
+		Short:   "Manage named workspaces with their own projects directories",
+		Long: `Manage named workspaces, such as personal, employer and oss.
+
+Each workspace has its own projects directory, folders and links, and can
+set its own editor, GitHub username and defaults; anything it leaves unset
+comes from the top-level config, which is the "default" workspace.
+
+'workspace use' picks the workspace commands run in; --workspace overrides
+it for a single command.`,
+	}
+
+	cmd.AddCommand(
+		newWorkspaceListCmd(),
+		newWorkspaceUseCmd(),
+		newWorkspaceAddCmd(),
+	)
+
+	return cmd
+}
+
+type workspaceInfo struct {
+	Name        string `json:"name"`
+	ProjectsDir string `json:"projects_dir"`
+	Folders     int    `json:"folders"`
+	Active      bool   `json:"active"`
+}
+
+func newWorkspaceListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List workspaces",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			base := runtime.Config.Base()
+			active := runtime.Config.ActiveWorkspace()
+			var infos []workspaceInfo
+			for _, name := range base.WorkspaceNames() {
+				cfg, err := base.ForWorkspace(name)
+				if err != nil {
+					return err
+				}
+				infos = append(infos, workspaceInfo{
+					Name:        name,
+					ProjectsDir: cfg.ProjectsDir,
+					Folders:     len(cfg.Folders),
+					Active:      name == active,
+				})
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), infos)
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.Header("🗂  Your Workspaces"))
+			fmt.Fprintln(w)
+
+			headers := []string{"", "Name", "Projects dir", "Folders"}
+			var rows [][]string
+			for _, info := range infos {
+				marker := ""
+				if info.Active {
+					marker = "*"
+				}
+				rows = append(rows, []string{marker, info.Name, info.ProjectsDir, fmt.Sprint(info.Folders)})
+			}
+			fmt.Fprintln(w, tui.Table(headers, rows))
+			if len(infos) == 1 {
+				fmt.Fprintln(w, tui.Muted("  Use 'projects workspace add <name> --dir <path>' to add another."))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newWorkspaceUseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a workspace the active one",
+		Long:  `Make a workspace the one commands run in. Use "default" for the top-level config.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			name := args[0]
+			base := runtime.Config.Base()
+			cfg, err := base.ForWorkspace(name)
+			if err != nil {
+				return err
+			}
+			if name == config.DefaultWorkspace {
+				base.Workspace = ""
+			} else {
+				base.Workspace = name
+			}
+			if err := config.SaveToPath(base, runtime.ConfigPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]string{
+					"status":       "active",
+					"workspace":    name,
+					"projects_dir": cfg.ProjectsDir,
+				})
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Now using workspace %s", tui.Slug(name))))
+			fmt.Fprintln(w, tui.FormatField("Projects dir", tui.Path(cfg.ProjectsDir)))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newWorkspaceAddCmd() *cobra.Command {
+	var (
+		ws                        config.Workspace
+		autoGitInit, installHooks bool
+		use                       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a workspace",
+		Long: `Add a named workspace with its own projects directory.
+
+--editor, --github-username, --commit-msg-pattern, --auto-git-init and
+--install-hooks override the top-level config in this workspace. Folders
+are added afterwards with 'projects folder add --workspace <name>'.`,
+		Example: "  projects workspace add oss --dir ~/oss --github-username octo-oss\n  projects workspace add employer --dir ~/work/projects --editor code --use",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runtime, ok := RuntimeFromContext(cmd.Context())
+			if !ok {
+				return fmt.Errorf("missing runtime context")
+			}
+
+			name := args[0]
+			if err := ValidateSlug(name); err != nil {
+				return fmt.Errorf("invalid workspace name: %w", err)
+			}
+			base := runtime.Config.Base()
+			if name == config.DefaultWorkspace || base.WorkspaceByName(name) != nil {
+				return fmt.Errorf("workspace %q already exists", name)
+			}
+			if ws.ProjectsDir == "" {
+				return fmt.Errorf("--dir is required: the workspace's projects directory")
+			}
+
+			dir, err := config.ExpandPath(ws.ProjectsDir)
+			if err != nil {
+				return err
+			}
+			if dir, err = filepath.Abs(dir); err != nil {
+				return err
+			}
+			for _, other := range base.WorkspaceNames() {
+				cfg, _ := base.ForWorkspace(other)
+				if filepath.Clean(cfg.ProjectsDir) == dir {
+					return fmt.Errorf("workspace %q already uses %s", other, dir)
+				}
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("create projects directory: %w", err)
+			}
+
+			ws.Name, ws.ProjectsDir = name, dir
+			if cmd.Flags().Changed("auto-git-init") {
+				ws.AutoGitInit = &autoGitInit
+			}
+			if cmd.Flags().Changed("install-hooks") {
+				ws.InstallHooks = &installHooks
+			}
+			base.Workspaces = append(base.Workspaces, ws)
+			if use {
+				base.Workspace = name
+			}
+			if err := config.SaveToPath(base, runtime.ConfigPath); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+
+			if tui.IsJSON() {
+				return writeJSON(cmd.OutOrStdout(), map[string]any{
+					"status":       "created",
+					"workspace":    name,
+					"projects_dir": dir,
+					"active":       use,
+				})
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, tui.SuccessMessage(fmt.Sprintf("Workspace %s created", tui.Slug(name))))
+			fmt.Fprintln(w, tui.FormatField("Projects dir", tui.Path(dir)))
+			if use {
+				fmt.Fprintln(w, tui.Muted("  It's now the active workspace."))
+			} else {
+				fmt.Fprintln(w, tui.Muted(fmt.Sprintf("  Switch to it with 'projects workspace use %s', or pass --workspace %s.", name, name)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&ws.ProjectsDir, "dir", "", "projects directory for the workspace (required)")
+	cmd.Flags().StringVar(&ws.Editor, "editor", "", "editor to use in this workspace")
+	cmd.Flags().StringVar(&ws.GitHubUsername, "github-username", "", "GitHub username for projects outside folders")
+	cmd.Flags().StringVar(&ws.CommitMsgPattern, "commit-msg-pattern", "", "commit message pattern enforced by the hooks")
+	cmd.Flags().BoolVar(&autoGitInit, "auto-git-init", true, "run git init in new projects")
+	cmd.Flags().BoolVar(&installHooks, "install-hooks", true, "install git hooks in new projects")
+	cmd.Flags().BoolVar(&use, "use", false, "make it the active workspace")
+
+	return cmd
+}
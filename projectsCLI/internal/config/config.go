@@ -3,34 +3,118 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	toml "github.com/pelletier/go-toml/v2"
 )
 
-// Folder represents a named project folder associated with a GitHub account.
+// Folder represents a named project folder associated with a forge account.
+// Forge defaults to GitHub; GitLab and Gitea/Forgejo folders also set BaseURL
+// for self-hosted instances, and GitHubAccount then names the user or group to
+// create repos under. The optional author, signing and SSH settings are
+// applied as repo-local git config to the folder's projects.
+//
+// Folders nest: the name work/clients/acme is the directory of that path
+// under the projects directory, and settings it leaves empty are inherited
+// from its configured ancestors (see FolderByName).
 type Folder struct {
 	Name          string `toml:"name"`
 	GitHubAccount string `toml:"github_account"`
+	Forge         string `toml:"forge,omitempty"`
+	BaseURL       string `toml:"base_url,omitempty"`
+	TokenEnv      string `toml:"token_env,omitempty"`
+	AuthorName    string `toml:"author_name,omitempty"`
+	AuthorEmail   string `toml:"author_email,omitempty"`
+	SigningKey    string `toml:"signing_key,omitempty"`
+	SSHCommand    string `toml:"ssh_command,omitempty"`
+	// Tags are added to the tags of projects created in the folder.
+	Tags []string `toml:"tags,omitempty"`
+	// Visibility is "private" or "public" for repos created from the folder.
+	Visibility string `toml:"visibility,omitempty"`
 }
 
 // Config holds all projectsCLI configuration fields.
 type Config struct {
-	ProjectsDir    string   `toml:"projects_dir,omitempty"`
-	Editor         string   `toml:"editor,omitempty"`
-	GitHubUsername string   `toml:"github_username,omitempty"`
-	AutoGitInit    bool     `toml:"auto_git_init"`
-	Folders        []Folder `toml:"folders,omitempty"`
+	ProjectsDir      string   `toml:"projects_dir,omitempty"`
+	Editor           string   `toml:"editor,omitempty"`
+	GitHubUsername   string   `toml:"github_username,omitempty"`
+	AutoGitInit      bool     `toml:"auto_git_init"`
+	InstallHooks     bool     `toml:"install_hooks"`
+	CommitMsgPattern string   `toml:"commit_msg_pattern,omitempty"`
+	Folders          []Folder `toml:"folders,omitempty"`
+	// Links are project directories, or directories of projects, that live
+	// outside ProjectsDir and are listed alongside it as external projects.
+	Links []string `toml:"links,omitempty"`
+
+	// Workspace is the workspace commands use when --workspace isn't given;
+	// empty means the top-level fields above. In a config returned by
+	// ForWorkspace it is the workspace that was applied.
+	Workspace  string      `toml:"workspace,omitempty"`
+	Workspaces []Workspace `toml:"workspaces,omitempty"`
+
+	// base is the config as loaded, set when a workspace has been applied.
+	base *Config
 }
 
-// FolderByName returns the folder with the given name, or nil if not found.
+// FolderByName returns the effective settings of the folder with the given
+// name, or nil if not found: its own settings, with empty ones filled in
+// from its configured ancestors, nearest first, and the ancestors' tags
+// added to its own. The result is a copy.
 func (c Config) FolderByName(name string) *Folder {
-	for i := range c.Folders {
-		if c.Folders[i].Name == name {
-			return &c.Folders[i]
+	chain := c.FolderChain(name)
+	if len(chain) == 0 || chain[len(chain)-1].Name != name {
+		return nil
+	}
+	f := chain[0]
+	for _, child := range chain[1:] {
+		f = inheritFolder(f, child)
+	}
+	return &f
+}
+
+// FolderChain returns the configured folders from the outermost ancestor of
+// name down to name itself; name need not be configured.
+func (c Config) FolderChain(name string) []Folder {
+	var chain []Folder
+	parts := strings.Split(name, "/")
+	for i := range parts {
+		prefix := strings.Join(parts[:i+1], "/")
+		for _, f := range c.Folders {
+			if f.Name == prefix {
+				chain = append(chain, f)
+				break
+			}
 		}
 	}
-	return nil
+	return chain
+}
+
+// inheritFolder returns child with its empty settings taken from parent.
+func inheritFolder(parent, child Folder) Folder {
+	fill := func(v *string, from string) {
+		if *v == "" {
+			*v = from
+		}
+	}
+	fill(&child.GitHubAccount, parent.GitHubAccount)
+	fill(&child.Forge, parent.Forge)
+	fill(&child.BaseURL, parent.BaseURL)
+	fill(&child.TokenEnv, parent.TokenEnv)
+	fill(&child.AuthorName, parent.AuthorName)
+	fill(&child.AuthorEmail, parent.AuthorEmail)
+	fill(&child.SigningKey, parent.SigningKey)
+	fill(&child.SSHCommand, parent.SSHCommand)
+	fill(&child.Visibility, parent.Visibility)
+
+	tags := append([]string(nil), parent.Tags...)
+	for _, t := range child.Tags {
+		if !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	child.Tags = tags
+	return child
 }
 
 // FolderNames returns a slice of all configured folder names.
@@ -50,9 +134,10 @@ func Defaults() Config {
 		editor = "vim"
 	}
 	return Config{
-		ProjectsDir: projDir,
-		Editor:      editor,
-		AutoGitInit: true,
+		ProjectsDir:  projDir,
+		Editor:       editor,
+		AutoGitInit:  true,
+		InstallHooks: true,
 	}
 }
 
@@ -69,7 +154,7 @@ func Load() (Config, error) {
 func LoadFromPath(path string) (Config, error) {
 	cfg := Defaults()
 
-	expanded, err := expandPath(path)
+	expanded, err := ExpandPath(path)
 	if err != nil {
 		return cfg, err
 	}
@@ -88,7 +173,17 @@ func LoadFromPath(path string) (Config, error) {
 
 	// Expand ~ in projects_dir if present.
 	if cfg.ProjectsDir != "" {
-		cfg.ProjectsDir, _ = expandPath(cfg.ProjectsDir)
+		cfg.ProjectsDir, _ = ExpandPath(cfg.ProjectsDir)
+	}
+	for i, link := range cfg.Links {
+		cfg.Links[i], _ = ExpandPath(link)
+	}
+	for i := range cfg.Workspaces {
+		w := &cfg.Workspaces[i]
+		w.ProjectsDir, _ = ExpandPath(w.ProjectsDir)
+		for j, link := range w.Links {
+			w.Links[j], _ = ExpandPath(link)
+		}
 	}
 
 	return cfg, nil
@@ -103,9 +198,11 @@ func Save(cfg Config) error {
 	return SaveToPath(cfg, path)
 }
 
-// SaveToPath writes config to a specific path.
+// SaveToPath writes config to a specific path. A workspace config is saved
+// into its workspace.
 func SaveToPath(cfg Config, path string) error {
-	expanded, err := expandPath(path)
+	cfg = cfg.stored()
+	expanded, err := ExpandPath(path)
 	if err != nil {
 		return err
 	}
@@ -122,7 +219,8 @@ func SaveToPath(cfg Config, path string) error {
 	return os.WriteFile(expanded, data, 0600)
 }
 
-func expandPath(path string) (string, error) {
+// ExpandPath expands a leading ~ in path to the home directory.
+func ExpandPath(path string) (string, error) {
 	if path == "~" || strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
 		if err != nil {
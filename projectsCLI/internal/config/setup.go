@@ -35,7 +35,7 @@ func NeedsSetup() bool {
 	if err != nil {
 		return true
 	}
-	expanded, err := expandPath(path)
+	expanded, err := ExpandPath(path)
 	if err != nil {
 		return true
 	}
@@ -115,7 +115,7 @@ func RunSetup() (Config, error) {
 
 	// If they picked the openclaw path, ensure that dir exists too.
 	if cfg.ProjectsDir != "" {
-		expanded, err := expandPath(cfg.ProjectsDir)
+		expanded, err := ExpandPath(cfg.ProjectsDir)
 		if err == nil {
 			_ = os.MkdirAll(expanded, 0700)
 		}
@@ -0,0 +1,133 @@
+package config
+
+import "fmt"
+
+// DefaultWorkspace names the workspace made of the top-level config fields.
+const DefaultWorkspace = "default"
+
+// Workspace is a named projects tree with its own folders and links. The
+// editor and defaults fall back to the top-level config when empty; the bool
+// pointers are nil when unset.
+type Workspace struct {
+	Name             string   `toml:"name"`
+	ProjectsDir      string   `toml:"projects_dir"`
+	Editor           string   `toml:"editor,omitempty"`
+	GitHubUsername   string   `toml:"github_username,omitempty"`
+	AutoGitInit      *bool    `toml:"auto_git_init,omitempty"`
+	InstallHooks     *bool    `toml:"install_hooks,omitempty"`
+	CommitMsgPattern string   `toml:"commit_msg_pattern,omitempty"`
+	Folders          []Folder `toml:"folders,omitempty"`
+	Links            []string `toml:"links,omitempty"`
+}
+
+// WorkspaceByName returns the workspace with the given name, or nil if not
+// found.
+func (c Config) WorkspaceByName(name string) *Workspace {
+	for i := range c.Workspaces {
+		if c.Workspaces[i].Name == name {
+			return &c.Workspaces[i]
+		}
+	}
+	return nil
+}
+
+// WorkspaceNames returns "default" followed by the configured workspaces.
+func (c Config) WorkspaceNames() []string {
+	names := []string{DefaultWorkspace}
+	for _, w := range c.Workspaces {
+		names = append(names, w.Name)
+	}
+	return names
+}
+
+// ActiveWorkspace returns the name of the workspace c is resolved to.
+func (c Config) ActiveWorkspace() string {
+	if c.base == nil {
+		return DefaultWorkspace
+	}
+	return c.Workspace
+}
+
+// ForWorkspace returns the config for the named workspace: its projects
+// dir, folders and links, with editor and defaults falling back to c. An
+// empty name or "default" returns the top-level config. Saving the result
+// writes changes back into the workspace.
+func (c Config) ForWorkspace(name string) (Config, error) {
+	base := c.Base()
+	if name == "" || name == DefaultWorkspace {
+		return base, nil
+	}
+	w := base.WorkspaceByName(name)
+	if w == nil {
+		return base, fmt.Errorf("workspace %q not configured; run 'projects workspace add %s --dir <path>' first", name, name)
+	}
+
+	cfg := base
+	cfg.Workspace = name
+	cfg.ProjectsDir = w.ProjectsDir
+	cfg.Folders = w.Folders
+	cfg.Links = w.Links
+	if w.Editor != "" {
+		cfg.Editor = w.Editor
+	}
+	if w.GitHubUsername != "" {
+		cfg.GitHubUsername = w.GitHubUsername
+	}
+	if w.AutoGitInit != nil {
+		cfg.AutoGitInit = *w.AutoGitInit
+	}
+	if w.InstallHooks != nil {
+		cfg.InstallHooks = *w.InstallHooks
+	}
+	if w.CommitMsgPattern != "" {
+		cfg.CommitMsgPattern = w.CommitMsgPattern
+	}
+	cfg.base = &base
+	return cfg, nil
+}
+
+// Base returns the config as stored, without any workspace applied.
+func (c Config) Base() Config {
+	if c.base == nil {
+		return c
+	}
+	return *c.base
+}
+
+// stored returns the config to write to disk: for a workspace config, the
+// base config with c's changes copied into the workspace. A field is
+// written to the workspace when the workspace already sets it or c no
+// longer matches the top-level value.
+func (c Config) stored() Config {
+	if c.base == nil {
+		return c
+	}
+	base := *c.base
+	base.Workspaces = append([]Workspace(nil), base.Workspaces...)
+	w := base.WorkspaceByName(c.Workspace)
+	if w == nil {
+		return base
+	}
+
+	w.ProjectsDir = c.ProjectsDir
+	w.Folders = c.Folders
+	w.Links = c.Links
+	if w.Editor != "" || c.Editor != base.Editor {
+		w.Editor = c.Editor
+	}
+	if w.GitHubUsername != "" || c.GitHubUsername != base.GitHubUsername {
+		w.GitHubUsername = c.GitHubUsername
+	}
+	if w.AutoGitInit != nil || c.AutoGitInit != base.AutoGitInit {
+		v := c.AutoGitInit
+		w.AutoGitInit = &v
+	}
+	if w.InstallHooks != nil || c.InstallHooks != base.InstallHooks {
+		v := c.InstallHooks
+		w.InstallHooks = &v
+	}
+	if w.CommitMsgPattern != "" || c.CommitMsgPattern != base.CommitMsgPattern {
+		w.CommitMsgPattern = c.CommitMsgPattern
+	}
+	return base
+}
@@ -0,0 +1,362 @@
+// Package forge talks to code hosting services (GitHub, GitLab, Gitea and
+// Forgejo) over their REST APIs: creating repositories, reading and updating
+// their settings, and listing the accounts a token can act for.
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kind identifies a forge implementation.
+type Kind string
+
+const (
+	GitHub Kind = "github"
+	GitLab Kind = "gitlab"
+	Gitea  Kind = "gitea" // also Forgejo, which keeps Gitea's API
+)
+
+// Kinds lists the supported forge kinds, for help text and validation.
+var Kinds = []Kind{GitHub, GitLab, Gitea}
+
+// ParseKind validates a forge name from config. Empty means GitHub and
+// "forgejo" is an alias for Gitea.
+func ParseKind(s string) (Kind, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "github":
+		return GitHub, nil
+	case "gitlab":
+		return GitLab, nil
+	case "gitea", "forgejo":
+		return Gitea, nil
+	}
+	return "", fmt.Errorf("unknown forge %q (want github, gitlab, gitea or forgejo)", s)
+}
+
+// Repo is a repository as the forge reports it.
+type Repo struct {
+	Owner       string   `json:"owner"`
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`       // web page
+	CloneURL    string   `json:"clone_url"` // HTTPS
+	SSHURL      string   `json:"ssh_url"`
+	Private     bool     `json:"private"`
+	Description string   `json:"description,omitempty"`
+	Homepage    string   `json:"homepage,omitempty"`
+	Topics      []string `json:"topics,omitempty"`
+}
+
+// HasHomepage reports whether the forge stores a homepage URL for repos.
+// GitLab has no such field.
+func (k Kind) HasHomepage() bool { return k != GitLab }
+
+// Meta is the descriptive metadata projects keeps in sync with a repo.
+type Meta struct {
+	Description string
+	Homepage    string
+	Topics      []string
+}
+
+// Issue is an issue (not a pull/merge request) on a repo.
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Closed bool   `json:"closed"`
+}
+
+// PullRequest is an open pull request (merge request on GitLab).
+type PullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Head   string `json:"head"` // source branch
+	Base   string `json:"base"` // target branch
+	Draft  bool   `json:"draft,omitempty"`
+}
+
+// PullRequestOptions describes a pull request to open.
+type PullRequestOptions struct {
+	Title string
+	Body  string
+	Head  string
+	Base  string
+	Draft bool
+}
+
+// CreateOptions describes a repository to create.
+type CreateOptions struct {
+	Owner       string // user or organisation/group; empty means the token's user
+	Name        string
+	Private     bool
+	Description string
+}
+
+// Provider is one forge account's view of a hosting service.
+type Provider interface {
+	Kind() Kind
+	// Accounts returns the authenticated user followed by the organisations
+	// (groups on GitLab) it can create repositories in.
+	Accounts(ctx context.Context) ([]string, error)
+	CreateRepo(ctx context.Context, opts CreateOptions) (*Repo, error)
+	GetRepo(ctx context.Context, owner, name string) (*Repo, error)
+	SetVisibility(ctx context.Context, owner, name string, private bool) error
+	// ArchiveRepo makes the repo read-only; DeleteRepo removes it for good.
+	ArchiveRepo(ctx context.Context, owner, name string) error
+	DeleteRepo(ctx context.Context, owner, name string) error
+	// TransferRepo moves owner/name to newOwner and returns it as it is after
+	// the request. A transfer to another user may wait for them to accept, in
+	// which case the returned repo still has the old owner.
+	TransferRepo(ctx context.Context, owner, name, newOwner string) (*Repo, error)
+	// UpdateRepo replaces the repo's description, homepage and topics.
+	UpdateRepo(ctx context.Context, owner, name string, meta Meta) error
+	// OpenIssues lists the repo's open issues, leaving out pull requests.
+	OpenIssues(ctx context.Context, owner, name string) ([]Issue, error)
+	CreateIssue(ctx context.Context, owner, name, title string) (*Issue, error)
+	SetIssueClosed(ctx context.Context, owner, name string, number int, closed bool) error
+	CreatePullRequest(ctx context.Context, owner, name string, opts PullRequestOptions) (*PullRequest, error)
+	OpenPullRequests(ctx context.Context, owner, name string) ([]PullRequest, error)
+	// RepoURL returns the web URL of owner/name without calling the API.
+	RepoURL(owner, name string) string
+}
+
+// Config selects and authenticates a provider.
+type Config struct {
+	Kind    Kind
+	BaseURL string // web root, e.g. https://gitea.example.com; empty for the public service
+	Token   string
+	// HTTPClient is used for API calls; nil means a client with a 30s timeout.
+	HTTPClient *http.Client
+}
+
+// New returns the provider for cfg.
+func New(cfg Config) (Provider, error) {
+	base := strings.TrimSuffix(cfg.BaseURL, "/")
+	if base != "" {
+		u, err := url.Parse(base)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid base_url %q", cfg.BaseURL)
+		}
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	switch cfg.Kind {
+	case GitHub, "":
+		return newGitHub(base, cfg.Token, httpClient), nil
+	case GitLab:
+		return newGitLab(base, cfg.Token, httpClient), nil
+	case Gitea:
+		if base == "" {
+			return nil, errors.New("gitea/forgejo needs a base_url")
+		}
+		return newGitea(base, cfg.Token, httpClient), nil
+	}
+	return nil, fmt.Errorf("unknown forge %q", cfg.Kind)
+}
+
+// ErrNotFound is returned when the forge reports a repository or account
+// doesn't exist (or the token can't see it).
+var ErrNotFound = errors.New("not found")
+
+// APIError is a non-2xx response from a forge API.
+type APIError struct {
+	Status  int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("forge API: %s", http.StatusText(e.Status))
+	}
+	return fmt.Sprintf("forge API: %s: %s", http.StatusText(e.Status), e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.Status == http.StatusNotFound
+}
+
+// client is the JSON-over-HTTP plumbing shared by the providers.
+type client struct {
+	api  string // API root, no trailing slash
+	http *http.Client
+	auth func(*http.Request)
+}
+
+// do sends in (if non-nil) as JSON to api+path and decodes the response into
+// out (if non-nil).
+func (c *client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.api+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.auth(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{Status: resp.StatusCode, Message: errorMessage(data)}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// maxPages bounds paginated listings.
+const maxPages = 50
+
+// issueState is the "state" value GitHub and Gitea use.
+func issueState(closed bool) string {
+	if closed {
+		return "closed"
+	}
+	return "open"
+}
+
+// nonNil keeps an empty list from being encoded as null, which the APIs
+// reject where they expect an array.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// errorMessage pulls the human-readable part out of an error body. The
+// forges disagree on the field name and GitLab sometimes nests it.
+func errorMessage(data []byte) string {
+	var body struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return strings.TrimSpace(string(data))
+	}
+	if body.Error != "" {
+		return body.Error
+	}
+	var s string
+	if json.Unmarshal(body.Message, &s) == nil {
+		return s
+	}
+	return string(body.Message)
+}
+
+// maxTopicLen is the shortest topic limit among the forges (Gitea's).
+const maxTopicLen = 35
+
+// Topics turns project tags into forge topics: lowercase letters, digits
+// and hyphens, deduplicated and sorted, which all three forges accept.
+// Tags with nothing usable left are dropped.
+func Topics(tags []string) []string {
+	seen := map[string]bool{}
+	var topics []string
+	for _, tag := range tags {
+		var b strings.Builder
+		for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+			switch {
+			case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+				b.WriteRune(r)
+			case r == '-' || r == '_' || r == ' ' || r == '.':
+				if s := b.String(); s != "" && !strings.HasSuffix(s, "-") {
+					b.WriteByte('-')
+				}
+			}
+		}
+		topic := b.String()
+		if len(topic) > maxTopicLen {
+			topic = topic[:maxTopicLen]
+		}
+		topic = strings.TrimRight(topic, "-")
+		if topic != "" && !seen[topic] {
+			seen[topic] = true
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// ParseRepoURL extracts owner and name from a clone or web URL in any of the
+// usual forms: https://host/owner/name(.git), ssh://git@host/owner/name.git
+// or git@host:owner/name.git. GitLab subgroups stay in owner ("group/sub").
+func ParseRepoURL(raw string) (host, owner, name string, err error) {
+	var path string
+	if u, perr := url.Parse(raw); perr == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if at, rest, ok := strings.Cut(raw, ":"); ok && !strings.Contains(at, "/") {
+		// scp-like syntax: [user@]host:path
+		if _, h, ok := strings.Cut(at, "@"); ok {
+			at = h
+		}
+		host, path = at, rest
+	} else {
+		return "", "", "", fmt.Errorf("unrecognised repository URL %q", raw)
+	}
+
+	path = strings.Trim(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	i := strings.LastIndex(path, "/")
+	if i <= 0 || i == len(path)-1 {
+		return "", "", "", fmt.Errorf("repository URL %q has no owner/name", raw)
+	}
+	return host, path[:i], path[i+1:], nil
+}
+
+// WebURL returns the web page of the repo at a clone or web URL. HTTP(S)
+// URLs keep their scheme and port; SSH URLs are assumed to be served over
+// HTTPS on the same host.
+func WebURL(raw string) (string, error) {
+	host, owner, name, err := ParseRepoURL(raw)
+	if err != nil {
+		return "", err
+	}
+	if u, err := url.Parse(raw); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return u.Scheme + "://" + u.Host + "/" + owner + "/" + name, nil
+	}
+	return "https://" + host + "/" + owner + "/" + name, nil
+}
+
+// SameRepo reports whether two clone or web URLs name the same repo.
+func SameRepo(a, b string) bool {
+	ha, oa, na, err := ParseRepoURL(a)
+	if err != nil {
+		return false
+	}
+	hb, ob, nb, err := ParseRepoURL(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ha, hb) && strings.EqualFold(oa, ob) && strings.EqualFold(na, nb)
+}
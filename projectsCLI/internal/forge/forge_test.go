@@ -0,0 +1,440 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recorded is one request the test server saw.
+type recorded struct {
+	Method, Path, Auth string
+	Body               map[string]any
+}
+
+// newServer serves routes ("METHOD /path?query" → JSON response) and records
+// every request. Unknown routes get 404.
+func newServer(t *testing.T, routes map[string]any) (*httptest.Server, *[]recorded) {
+	t.Helper()
+	var reqs []recorded
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recorded{Method: r.Method, Path: r.URL.EscapedPath()}
+		for _, h := range []string{"Authorization", "PRIVATE-TOKEN"} {
+			if v := r.Header.Get(h); v != "" {
+				rec.Auth = h + ": " + v
+			}
+		}
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&rec.Body)
+		}
+		reqs = append(reqs, rec)
+
+		key := r.Method + " " + rec.Path
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		resp, ok := routes[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &reqs
+}
+
+func mustNew(t *testing.T, cfg Config) Provider {
+	t.Helper()
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGitHub(t *testing.T) {
+	repo := map[string]any{
+		"name": "demo", "owner": map[string]any{"login": "acme"},
+		"html_url": "https://ghe.test/acme/demo", "clone_url": "https://ghe.test/acme/demo.git",
+		"ssh_url": "git@ghe.test:acme/demo.git", "private": true, "homepage": "https://demo.dev",
+		"topics": []string{"cli"},
+	}
+	srv, reqs := newServer(t, map[string]any{
+		"GET /api/v3/user":                   map[string]any{"login": "octo"},
+		"GET /api/v3/user/orgs?per_page=100": []any{map[string]any{"login": "acme"}},
+		"POST /api/v3/orgs/acme/repos":       repo,
+		"GET /api/v3/repos/acme/demo":        repo,
+		"PATCH /api/v3/repos/acme/demo":      repo,
+	})
+	p := mustNew(t, Config{Kind: GitHub, BaseURL: srv.URL + "/", Token: "t0k"})
+	ctx := context.Background()
+
+	accounts, err := p.Accounts(ctx)
+	if err != nil || len(accounts) != 2 || accounts[0] != "octo" || accounts[1] != "acme" {
+		t.Fatalf("Accounts = %v, %v", accounts, err)
+	}
+	created, err := p.CreateRepo(ctx, CreateOptions{Owner: "acme", Name: "demo", Private: true, Description: "d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Owner != "acme" || created.SSHURL != "git@ghe.test:acme/demo.git" || created.Homepage != "https://demo.dev" {
+		t.Errorf("CreateRepo = %+v", created)
+	}
+	if err := p.SetVisibility(ctx, "acme", "demo", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GetRepo(ctx, "acme", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRepo(missing) err = %v, want ErrNotFound", err)
+	}
+
+	last := (*reqs)[len(*reqs)-2]
+	if last.Method != http.MethodPatch || last.Body["private"] != false {
+		t.Errorf("SetVisibility sent %+v", last)
+	}
+	if (*reqs)[0].Auth != "Authorization: Bearer t0k" {
+		t.Errorf("auth = %q", (*reqs)[0].Auth)
+	}
+	if got := p.RepoURL("acme", "demo"); got != srv.URL+"/acme/demo" {
+		t.Errorf("RepoURL = %q", got)
+	}
+}
+
+func TestGitHubPublicDefaults(t *testing.T) {
+	p := mustNew(t, Config{Kind: GitHub})
+	if got := p.RepoURL("octo", "demo"); got != "https://github.com/octo/demo" {
+		t.Errorf("RepoURL = %q", got)
+	}
+}
+
+func TestGitLab(t *testing.T) {
+	project := map[string]any{
+		"path": "demo", "namespace": map[string]any{"full_path": "team/sub"},
+		"web_url": "https://gl.test/team/sub/demo", "http_url_to_repo": "https://gl.test/team/sub/demo.git",
+		"ssh_url_to_repo": "git@gl.test:team/sub/demo.git", "visibility": "internal",
+	}
+	srv, reqs := newServer(t, map[string]any{
+		"GET /api/v4/user": map[string]any{"username": "me"},
+		"GET /api/v4/groups?min_access_level=30&per_page=100": []any{map[string]any{"full_path": "team/sub"}},
+		"GET /api/v4/namespaces/team%2Fsub":                   map[string]any{"id": 42, "kind": "group"},
+		"POST /api/v4/projects":                               project,
+		"GET /api/v4/projects/team%2Fsub%2Fdemo":              project,
+		"PUT /api/v4/projects/team%2Fsub%2Fdemo":              project,
+	})
+	p := mustNew(t, Config{Kind: GitLab, BaseURL: srv.URL, Token: "glpat"})
+	ctx := context.Background()
+
+	accounts, err := p.Accounts(ctx)
+	if err != nil || len(accounts) != 2 || accounts[1] != "team/sub" {
+		t.Fatalf("Accounts = %v, %v", accounts, err)
+	}
+	if _, err := p.CreateRepo(ctx, CreateOptions{Owner: "team/sub", Name: "demo", Private: true}); err != nil {
+		t.Fatal(err)
+	}
+	create := (*reqs)[3]
+	if create.Body["namespace_id"] != float64(42) || create.Body["visibility"] != "private" {
+		t.Errorf("CreateRepo sent %+v", create.Body)
+	}
+	r, err := p.GetRepo(ctx, "team/sub", "demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Owner != "team/sub" || !r.Private {
+		t.Errorf("GetRepo = %+v (internal counts as private)", r)
+	}
+	if err := p.SetVisibility(ctx, "team/sub", "demo", false); err != nil {
+		t.Fatal(err)
+	}
+	if put := (*reqs)[len(*reqs)-1]; put.Body["visibility"] != "public" {
+		t.Errorf("SetVisibility sent %+v", put.Body)
+	}
+	if create.Auth != "PRIVATE-TOKEN: glpat" {
+		t.Errorf("auth = %q", create.Auth)
+	}
+}
+
+func TestGitea(t *testing.T) {
+	repo := map[string]any{
+		"name": "demo", "owner": map[string]any{"login": "me"},
+		"html_url": "https://tea.test/me/demo", "clone_url": "https://tea.test/me/demo.git",
+		"ssh_url": "git@tea.test:me/demo.git", "website": "https://demo.dev",
+	}
+	srv, reqs := newServer(t, map[string]any{
+		"GET /api/v1/user":               map[string]any{"login": "me"},
+		"GET /api/v1/user/orgs?limit=50": []any{map[string]any{"username": "club"}},
+		"POST /api/v1/user/repos":        repo,
+		"GET /api/v1/repos/me/demo":      repo,
+	})
+	if _, err := New(Config{Kind: Gitea}); err == nil {
+		t.Error("New(gitea) without base_url succeeded")
+	}
+	p := mustNew(t, Config{Kind: Gitea, BaseURL: srv.URL, Token: "tea"})
+	ctx := context.Background()
+
+	accounts, err := p.Accounts(ctx)
+	if err != nil || len(accounts) != 2 || accounts[1] != "club" {
+		t.Fatalf("Accounts = %v, %v", accounts, err)
+	}
+	r, err := p.CreateRepo(ctx, CreateOptions{Owner: "me", Name: "demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Homepage != "https://demo.dev" || r.CloneURL != "https://tea.test/me/demo.git" {
+		t.Errorf("CreateRepo = %+v", r)
+	}
+	if (*reqs)[0].Auth != "Authorization: token tea" {
+		t.Errorf("auth = %q", (*reqs)[0].Auth)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":{"name":["has already been taken"]}}`))
+	}))
+	defer srv.Close()
+
+	p := mustNew(t, Config{Kind: GitLab, BaseURL: srv.URL})
+	_, err := p.CreateRepo(context.Background(), CreateOptions{Name: "demo"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("err = %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("422 matched ErrNotFound")
+	}
+	if want := `{"name":["has already been taken"]}`; apiErr.Message != want {
+		t.Errorf("message = %q, want %q", apiErr.Message, want)
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	for in, want := range map[string]Kind{"": GitHub, "GitLab": GitLab, "forgejo": Gitea, "gitea": Gitea} {
+		if got, err := ParseKind(in); err != nil || got != want {
+			t.Errorf("ParseKind(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseKind("bitbucket"); err == nil {
+		t.Error("ParseKind(bitbucket) succeeded")
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		in, host, owner, name string
+	}{
+		{"https://github.com/octo/demo.git", "github.com", "octo", "demo"},
+		{"https://gitlab.com/team/sub/demo", "gitlab.com", "team/sub", "demo"},
+		{"git@tea.test:me/demo.git", "tea.test", "me", "demo"},
+		{"ssh://git@tea.test:2222/me/demo.git", "tea.test", "me", "demo"},
+	}
+	for _, tt := range tests {
+		host, owner, name, err := ParseRepoURL(tt.in)
+		if err != nil || host != tt.host || owner != tt.owner || name != tt.name {
+			t.Errorf("ParseRepoURL(%q) = %q, %q, %q, %v", tt.in, host, owner, name, err)
+		}
+	}
+	for _, bad := range []string{"demo", "https://github.com/demo", "/local/path"} {
+		if _, _, _, err := ParseRepoURL(bad); err == nil {
+			t.Errorf("ParseRepoURL(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestWebURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"http://127.0.0.1:3000/me/demo.git":   "http://127.0.0.1:3000/me/demo",
+		"git@github.com:octo/demo.git":        "https://github.com/octo/demo",
+		"ssh://git@tea.test:2222/me/demo.git": "https://tea.test/me/demo",
+	} {
+		if got, err := WebURL(in); err != nil || got != want {
+			t.Errorf("WebURL(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if !SameRepo("git@github.com:Octo/demo.git", "https://github.com/octo/demo") {
+		t.Error("SameRepo should ignore URL form and case")
+	}
+	if SameRepo("https://github.com/octo/demo", "https://github.com/octo/other") {
+		t.Error("SameRepo matched different repos")
+	}
+}
+
+func TestUpdateRepo(t *testing.T) {
+	routes := map[string]any{
+		"PATCH /api/v3/repos/octo/demo":      map[string]any{},
+		"PUT /api/v3/repos/octo/demo/topics": map[string]any{},
+		"PUT /api/v4/projects/octo%2Fdemo":   map[string]any{},
+	}
+	srv, reqs := newServer(t, routes)
+	meta := Meta{Description: "A demo", Homepage: "https://demo.dev"}
+	ctx := context.Background()
+
+	if err := mustNew(t, Config{Kind: GitHub, BaseURL: srv.URL}).UpdateRepo(ctx, "octo", "demo", meta); err != nil {
+		t.Fatal(err)
+	}
+	patch, topics := (*reqs)[0], (*reqs)[1]
+	if patch.Body["description"] != "A demo" || patch.Body["homepage"] != "https://demo.dev" {
+		t.Errorf("GitHub PATCH sent %+v", patch.Body)
+	}
+	// Clearing topics must send [], not null.
+	if names, ok := topics.Body["names"].([]any); !ok || len(names) != 0 {
+		t.Errorf("GitHub topics sent %+v", topics.Body)
+	}
+
+	meta.Topics = []string{"cli"}
+	if err := mustNew(t, Config{Kind: GitLab, BaseURL: srv.URL}).UpdateRepo(ctx, "octo", "demo", meta); err != nil {
+		t.Fatal(err)
+	}
+	put := (*reqs)[2]
+	if _, ok := put.Body["homepage"]; ok || put.Body["topics"].([]any)[0] != "cli" {
+		t.Errorf("GitLab PUT sent %+v", put.Body)
+	}
+}
+
+func TestTopics(t *testing.T) {
+	got := Topics([]string{"Go", "CLI tools", "go", "c++", "dev_ops", "--", "a-very-long-tag-that-goes-on-and-on-and-on"})
+	want := []string{"a-very-long-tag-that-goes-on-and-on", "c", "cli-tools", "dev-ops", "go"}
+	if len(got) != len(want) {
+		t.Fatalf("Topics = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Topics[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestIssues(t *testing.T) {
+	srv, reqs := newServer(t, map[string]any{
+		"GET /api/v3/repos/octo/demo/issues?state=open&per_page=100&page=1": []any{
+			map[string]any{"number": 1, "title": "Bug", "state": "open"},
+			map[string]any{"number": 2, "title": "A PR", "state": "open", "pull_request": map[string]any{"url": "x"}},
+		},
+		"POST /api/v3/repos/octo/demo/issues":    map[string]any{"number": 3, "title": "New", "state": "open"},
+		"PATCH /api/v3/repos/octo/demo/issues/1": map[string]any{},
+		"GET /api/v4/projects/octo%2Fdemo/issues?state=opened&per_page=100&page=1": []any{
+			map[string]any{"iid": 4, "title": "GitLab bug", "state": "opened"},
+		},
+		"PUT /api/v4/projects/octo%2Fdemo/issues/4": map[string]any{},
+	})
+	ctx := context.Background()
+
+	gh := mustNew(t, Config{Kind: GitHub, BaseURL: srv.URL})
+	issues, err := gh.OpenIssues(ctx, "octo", "demo")
+	if err != nil || len(issues) != 1 || issues[0].Number != 1 {
+		t.Fatalf("GitHub OpenIssues = %+v, %v (pull requests must be skipped)", issues, err)
+	}
+	created, err := gh.CreateIssue(ctx, "octo", "demo", "New")
+	if err != nil || created.Number != 3 {
+		t.Fatalf("CreateIssue = %+v, %v", created, err)
+	}
+	if err := gh.SetIssueClosed(ctx, "octo", "demo", 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if body := (*reqs)[2].Body; body["state"] != "closed" {
+		t.Errorf("close sent %+v", body)
+	}
+
+	gl := mustNew(t, Config{Kind: GitLab, BaseURL: srv.URL})
+	issues, err = gl.OpenIssues(ctx, "octo", "demo")
+	if err != nil || len(issues) != 1 || issues[0].Number != 4 || issues[0].Closed {
+		t.Fatalf("GitLab OpenIssues = %+v, %v", issues, err)
+	}
+	if err := gl.SetIssueClosed(ctx, "octo", "demo", 4, false); err != nil {
+		t.Fatal(err)
+	}
+	if body := (*reqs)[len(*reqs)-1].Body; body["state_event"] != "reopen" {
+		t.Errorf("reopen sent %+v", body)
+	}
+}
+
+func TestPullRequests(t *testing.T) {
+	pull := map[string]any{
+		"number": 5, "title": "Add login", "html_url": "https://ghe.test/octo/demo/pull/5",
+		"head": map[string]any{"ref": "login"}, "base": map[string]any{"ref": "main"},
+	}
+	srv, reqs := newServer(t, map[string]any{
+		"POST /api/v3/repos/octo/demo/pulls":                               pull,
+		"GET /api/v3/repos/octo/demo/pulls?state=open&per_page=100&page=1": []any{pull},
+		"POST /api/v4/projects/octo%2Fdemo/merge_requests": map[string]any{
+			"iid": 2, "title": "Draft: Add login", "source_branch": "login", "target_branch": "main", "draft": true,
+		},
+	})
+	ctx := context.Background()
+	opts := PullRequestOptions{Title: "Add login", Body: "Closes #1", Head: "login", Base: "main", Draft: true}
+
+	gh := mustNew(t, Config{Kind: GitHub, BaseURL: srv.URL})
+	pr, err := gh.CreatePullRequest(ctx, "octo", "demo", opts)
+	if err != nil || pr.Number != 5 || pr.Head != "login" || pr.Base != "main" {
+		t.Fatalf("CreatePullRequest = %+v, %v", pr, err)
+	}
+	if body := (*reqs)[0].Body; body["draft"] != true || body["body"] != "Closes #1" {
+		t.Errorf("GitHub sent %+v", body)
+	}
+	if pulls, err := gh.OpenPullRequests(ctx, "octo", "demo"); err != nil || len(pulls) != 1 || pulls[0].URL == "" {
+		t.Errorf("OpenPullRequests = %+v, %v", pulls, err)
+	}
+
+	gl := mustNew(t, Config{Kind: GitLab, BaseURL: srv.URL})
+	pr, err = gl.CreatePullRequest(ctx, "octo", "demo", opts)
+	if err != nil || !pr.Draft || pr.Head != "login" {
+		t.Fatalf("GitLab CreatePullRequest = %+v, %v", pr, err)
+	}
+	if body := (*reqs)[2].Body; body["title"] != "Draft: Add login" || body["description"] != "Closes #1" {
+		t.Errorf("GitLab sent %+v", body)
+	}
+}
+
+func TestRepoLifecycle(t *testing.T) {
+	srv, reqs := newServer(t, map[string]any{
+		"PATCH /api/v3/repos/octo/demo":             map[string]any{},
+		"DELETE /api/v3/repos/octo/demo":            nil,
+		"POST /api/v3/repos/octo/demo/transfer":     map[string]any{"name": "demo", "owner": map[string]any{"login": "acme"}},
+		"POST /api/v4/projects/octo%2Fdemo/archive": map[string]any{},
+		"PUT /api/v4/projects/octo%2Fdemo/transfer": map[string]any{"path": "demo", "namespace": map[string]any{"full_path": "team"}},
+		"POST /api/v1/repos/me/demo/transfer":       map[string]any{"name": "demo", "owner": map[string]any{"login": "club"}},
+		"DELETE /api/v4/projects/octo%2Fdemo":       nil,
+		"PATCH /api/v1/repos/me/demo":               map[string]any{},
+	})
+	ctx := context.Background()
+
+	gh := mustNew(t, Config{Kind: GitHub, BaseURL: srv.URL})
+	if err := gh.ArchiveRepo(ctx, "octo", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	if (*reqs)[0].Body["archived"] != true {
+		t.Errorf("GitHub archive sent %+v", (*reqs)[0].Body)
+	}
+	if err := gh.DeleteRepo(ctx, "octo", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := gh.TransferRepo(ctx, "octo", "demo", "acme")
+	if err != nil || repo.Owner != "acme" || (*reqs)[2].Body["new_owner"] != "acme" {
+		t.Errorf("GitHub TransferRepo = %+v, %v, sent %+v", repo, err, (*reqs)[2].Body)
+	}
+
+	gl := mustNew(t, Config{Kind: GitLab, BaseURL: srv.URL})
+	if err := gl.ArchiveRepo(ctx, "octo", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gl.DeleteRepo(ctx, "octo", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	repo, err = gl.TransferRepo(ctx, "octo", "demo", "team")
+	if err != nil || repo.Owner != "team" || (*reqs)[5].Body["namespace"] != "team" {
+		t.Errorf("GitLab TransferRepo = %+v, %v", repo, err)
+	}
+
+	tea := mustNew(t, Config{Kind: Gitea, BaseURL: srv.URL})
+	if err := tea.ArchiveRepo(ctx, "me", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	if repo, err := tea.TransferRepo(ctx, "me", "demo", "club"); err != nil || repo.Owner != "club" {
+		t.Errorf("Gitea TransferRepo = %+v, %v", repo, err)
+	}
+}
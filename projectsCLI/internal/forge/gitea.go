@@ -0,0 +1,248 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// gitea implements Provider for self-hosted Gitea and Forgejo.
+type gitea struct {
+	client
+	web string
+}
+
+func newGitea(base, token string, hc *http.Client) *gitea {
+	return &gitea{
+		client: client{api: base + "/api/v1", http: hc, auth: func(r *http.Request) {
+			if token != "" {
+				r.Header.Set("Authorization", "token "+token)
+			}
+		}},
+		web: base,
+	}
+}
+
+func (g *gitea) Kind() Kind { return Gitea }
+
+func (g *gitea) RepoURL(owner, name string) string {
+	return g.web + "/" + owner + "/" + name
+}
+
+type giteaRepo struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	HTMLURL     string   `json:"html_url"`
+	CloneURL    string   `json:"clone_url"`
+	SSHURL      string   `json:"ssh_url"`
+	Private     bool     `json:"private"`
+	Description string   `json:"description"`
+	Website     string   `json:"website"`
+	Topics      []string `json:"topics"`
+}
+
+func (r giteaRepo) repo() *Repo {
+	return &Repo{
+		Owner:       r.Owner.Login,
+		Name:        r.Name,
+		URL:         r.HTMLURL,
+		CloneURL:    r.CloneURL,
+		SSHURL:      r.SSHURL,
+		Private:     r.Private,
+		Description: r.Description,
+		Homepage:    r.Website,
+		Topics:      r.Topics,
+	}
+}
+
+func (g *gitea) user(ctx context.Context) (string, error) {
+	var u struct {
+		Login string `json:"login"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, &u); err != nil {
+		return "", err
+	}
+	return u.Login, nil
+}
+
+func (g *gitea) Accounts(ctx context.Context) ([]string, error) {
+	login, err := g.user(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var orgs []struct {
+		Username string `json:"username"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/user/orgs?limit=50", nil, &orgs); err != nil {
+		return nil, err
+	}
+	accounts := []string{login}
+	for _, o := range orgs {
+		accounts = append(accounts, o.Username)
+	}
+	return accounts, nil
+}
+
+func (g *gitea) CreateRepo(ctx context.Context, opts CreateOptions) (*Repo, error) {
+	path := "/user/repos"
+	if opts.Owner != "" {
+		login, err := g.user(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(login, opts.Owner) {
+			path = "/orgs/" + url.PathEscape(opts.Owner) + "/repos"
+		}
+	}
+	in := map[string]any{
+		"name":        opts.Name,
+		"private":     opts.Private,
+		"description": opts.Description,
+	}
+	var out giteaRepo
+	if err := g.do(ctx, http.MethodPost, path, in, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *gitea) GetRepo(ctx context.Context, owner, name string) (*Repo, error) {
+	var out giteaRepo
+	if err := g.do(ctx, http.MethodGet, g.repoPath(owner, name), nil, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *gitea) SetVisibility(ctx context.Context, owner, name string, private bool) error {
+	return g.do(ctx, http.MethodPatch, g.repoPath(owner, name), map[string]any{"private": private}, nil)
+}
+
+func (g *gitea) ArchiveRepo(ctx context.Context, owner, name string) error {
+	return g.do(ctx, http.MethodPatch, g.repoPath(owner, name), map[string]any{"archived": true}, nil)
+}
+
+func (g *gitea) DeleteRepo(ctx context.Context, owner, name string) error {
+	return g.do(ctx, http.MethodDelete, g.repoPath(owner, name), nil, nil)
+}
+
+func (g *gitea) TransferRepo(ctx context.Context, owner, name, newOwner string) (*Repo, error) {
+	var out giteaRepo
+	if err := g.do(ctx, http.MethodPost, g.repoPath(owner, name)+"/transfer", map[string]any{"new_owner": newOwner}, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *gitea) UpdateRepo(ctx context.Context, owner, name string, meta Meta) error {
+	in := map[string]any{"description": meta.Description, "website": meta.Homepage}
+	if err := g.do(ctx, http.MethodPatch, g.repoPath(owner, name), in, nil); err != nil {
+		return err
+	}
+	topics := map[string]any{"topics": nonNil(meta.Topics)}
+	return g.do(ctx, http.MethodPut, g.repoPath(owner, name)+"/topics", topics, nil)
+}
+
+type giteaIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+}
+
+func (i giteaIssue) issue() Issue {
+	return Issue{Number: i.Number, Title: i.Title, URL: i.HTMLURL, Closed: i.State == "closed"}
+}
+
+func (g *gitea) OpenIssues(ctx context.Context, owner, name string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; page <= maxPages; page++ {
+		var batch []giteaIssue
+		path := g.repoPath(owner, name) + "/issues?state=open&type=issues&limit=50&page=" + strconv.Itoa(page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, i := range batch {
+			issues = append(issues, i.issue())
+		}
+		if len(batch) < 50 {
+			break
+		}
+	}
+	return issues, nil
+}
+
+func (g *gitea) CreateIssue(ctx context.Context, owner, name, title string) (*Issue, error) {
+	var out giteaIssue
+	if err := g.do(ctx, http.MethodPost, g.repoPath(owner, name)+"/issues", map[string]any{"title": title}, &out); err != nil {
+		return nil, err
+	}
+	issue := out.issue()
+	return &issue, nil
+}
+
+func (g *gitea) SetIssueClosed(ctx context.Context, owner, name string, number int, closed bool) error {
+	path := g.repoPath(owner, name) + "/issues/" + strconv.Itoa(number)
+	return g.do(ctx, http.MethodPatch, path, map[string]any{"state": issueState(closed)}, nil)
+}
+
+type giteaPull struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (p giteaPull) pull() PullRequest {
+	return PullRequest{Number: p.Number, Title: p.Title, URL: p.HTMLURL, Head: p.Head.Ref, Base: p.Base.Ref}
+}
+
+// CreatePullRequest marks drafts with Gitea's "WIP:" title prefix; the API
+// has no draft flag.
+func (g *gitea) CreatePullRequest(ctx context.Context, owner, name string, opts PullRequestOptions) (*PullRequest, error) {
+	title := opts.Title
+	if opts.Draft {
+		title = "WIP: " + title
+	}
+	in := map[string]any{"title": title, "body": opts.Body, "head": opts.Head, "base": opts.Base}
+	var out giteaPull
+	if err := g.do(ctx, http.MethodPost, g.repoPath(owner, name)+"/pulls", in, &out); err != nil {
+		return nil, err
+	}
+	pr := out.pull()
+	pr.Draft = opts.Draft
+	return &pr, nil
+}
+
+func (g *gitea) OpenPullRequests(ctx context.Context, owner, name string) ([]PullRequest, error) {
+	var pulls []PullRequest
+	for page := 1; page <= maxPages; page++ {
+		var batch []giteaPull
+		path := g.repoPath(owner, name) + "/pulls?state=open&limit=50&page=" + strconv.Itoa(page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, p := range batch {
+			pr := p.pull()
+			pr.Draft = strings.HasPrefix(pr.Title, "WIP:")
+			pulls = append(pulls, pr)
+		}
+		if len(batch) < 50 {
+			break
+		}
+	}
+	return pulls, nil
+}
+
+func (g *gitea) repoPath(owner, name string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
@@ -0,0 +1,252 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// github implements Provider for github.com and GitHub Enterprise Server.
+type github struct {
+	client
+	web string
+}
+
+func newGitHub(base, token string, hc *http.Client) *github {
+	web, api := "https://github.com", "https://api.github.com"
+	if base != "" && base != web {
+		web, api = base, base+"/api/v3"
+	}
+	return &github{
+		client: client{api: api, http: hc, auth: func(r *http.Request) {
+			r.Header.Set("Accept", "application/vnd.github+json")
+			r.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+			if token != "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+		}},
+		web: web,
+	}
+}
+
+func (g *github) Kind() Kind { return GitHub }
+
+func (g *github) RepoURL(owner, name string) string {
+	return g.web + "/" + owner + "/" + name
+}
+
+type githubRepo struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	HTMLURL     string   `json:"html_url"`
+	CloneURL    string   `json:"clone_url"`
+	SSHURL      string   `json:"ssh_url"`
+	Private     bool     `json:"private"`
+	Description string   `json:"description"`
+	Homepage    string   `json:"homepage"`
+	Topics      []string `json:"topics"`
+}
+
+func (r githubRepo) repo() *Repo {
+	return &Repo{
+		Owner:       r.Owner.Login,
+		Name:        r.Name,
+		URL:         r.HTMLURL,
+		CloneURL:    r.CloneURL,
+		SSHURL:      r.SSHURL,
+		Private:     r.Private,
+		Description: r.Description,
+		Homepage:    r.Homepage,
+		Topics:      r.Topics,
+	}
+}
+
+func (g *github) user(ctx context.Context) (string, error) {
+	var u struct {
+		Login string `json:"login"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, &u); err != nil {
+		return "", err
+	}
+	return u.Login, nil
+}
+
+func (g *github) Accounts(ctx context.Context) ([]string, error) {
+	login, err := g.user(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var orgs []struct {
+		Login string `json:"login"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/user/orgs?per_page=100", nil, &orgs); err != nil {
+		return nil, err
+	}
+	accounts := []string{login}
+	for _, o := range orgs {
+		accounts = append(accounts, o.Login)
+	}
+	return accounts, nil
+}
+
+func (g *github) CreateRepo(ctx context.Context, opts CreateOptions) (*Repo, error) {
+	path := "/user/repos"
+	if opts.Owner != "" {
+		login, err := g.user(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(login, opts.Owner) {
+			path = "/orgs/" + url.PathEscape(opts.Owner) + "/repos"
+		}
+	}
+	in := map[string]any{
+		"name":        opts.Name,
+		"private":     opts.Private,
+		"description": opts.Description,
+	}
+	var out githubRepo
+	if err := g.do(ctx, http.MethodPost, path, in, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *github) GetRepo(ctx context.Context, owner, name string) (*Repo, error) {
+	var out githubRepo
+	if err := g.do(ctx, http.MethodGet, g.repoPath(owner, name), nil, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *github) SetVisibility(ctx context.Context, owner, name string, private bool) error {
+	return g.do(ctx, http.MethodPatch, g.repoPath(owner, name), map[string]any{"private": private}, nil)
+}
+
+func (g *github) ArchiveRepo(ctx context.Context, owner, name string) error {
+	return g.do(ctx, http.MethodPatch, g.repoPath(owner, name), map[string]any{"archived": true}, nil)
+}
+
+func (g *github) DeleteRepo(ctx context.Context, owner, name string) error {
+	return g.do(ctx, http.MethodDelete, g.repoPath(owner, name), nil, nil)
+}
+
+func (g *github) TransferRepo(ctx context.Context, owner, name, newOwner string) (*Repo, error) {
+	var out githubRepo
+	if err := g.do(ctx, http.MethodPost, g.repoPath(owner, name)+"/transfer", map[string]any{"new_owner": newOwner}, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *github) UpdateRepo(ctx context.Context, owner, name string, meta Meta) error {
+	in := map[string]any{"description": meta.Description, "homepage": meta.Homepage}
+	if err := g.do(ctx, http.MethodPatch, g.repoPath(owner, name), in, nil); err != nil {
+		return err
+	}
+	topics := map[string]any{"names": nonNil(meta.Topics)}
+	return g.do(ctx, http.MethodPut, g.repoPath(owner, name)+"/topics", topics, nil)
+}
+
+type githubIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	// PullRequest is set when the "issue" is really a pull request.
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request"`
+}
+
+func (i githubIssue) issue() Issue {
+	return Issue{Number: i.Number, Title: i.Title, URL: i.HTMLURL, Closed: i.State == "closed"}
+}
+
+func (g *github) OpenIssues(ctx context.Context, owner, name string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; page <= maxPages; page++ {
+		var batch []githubIssue
+		path := g.repoPath(owner, name) + "/issues?state=open&per_page=100&page=" + strconv.Itoa(page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, i := range batch {
+			if i.PullRequest == nil {
+				issues = append(issues, i.issue())
+			}
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+	return issues, nil
+}
+
+func (g *github) CreateIssue(ctx context.Context, owner, name, title string) (*Issue, error) {
+	var out githubIssue
+	if err := g.do(ctx, http.MethodPost, g.repoPath(owner, name)+"/issues", map[string]any{"title": title}, &out); err != nil {
+		return nil, err
+	}
+	issue := out.issue()
+	return &issue, nil
+}
+
+func (g *github) SetIssueClosed(ctx context.Context, owner, name string, number int, closed bool) error {
+	path := g.repoPath(owner, name) + "/issues/" + strconv.Itoa(number)
+	return g.do(ctx, http.MethodPatch, path, map[string]any{"state": issueState(closed)}, nil)
+}
+
+type githubPull struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	Draft   bool   `json:"draft"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (p githubPull) pull() PullRequest {
+	return PullRequest{Number: p.Number, Title: p.Title, URL: p.HTMLURL, Head: p.Head.Ref, Base: p.Base.Ref, Draft: p.Draft}
+}
+
+func (g *github) CreatePullRequest(ctx context.Context, owner, name string, opts PullRequestOptions) (*PullRequest, error) {
+	in := map[string]any{"title": opts.Title, "body": opts.Body, "head": opts.Head, "base": opts.Base, "draft": opts.Draft}
+	var out githubPull
+	if err := g.do(ctx, http.MethodPost, g.repoPath(owner, name)+"/pulls", in, &out); err != nil {
+		return nil, err
+	}
+	pr := out.pull()
+	return &pr, nil
+}
+
+func (g *github) OpenPullRequests(ctx context.Context, owner, name string) ([]PullRequest, error) {
+	var pulls []PullRequest
+	for page := 1; page <= maxPages; page++ {
+		var batch []githubPull
+		path := g.repoPath(owner, name) + "/pulls?state=open&per_page=100&page=" + strconv.Itoa(page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, p := range batch {
+			pulls = append(pulls, p.pull())
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+	return pulls, nil
+}
+
+func (g *github) repoPath(owner, name string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
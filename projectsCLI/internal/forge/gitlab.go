@@ -0,0 +1,256 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// gitlab implements Provider for gitlab.com and self-managed GitLab.
+type gitlab struct {
+	client
+	web string
+}
+
+func newGitLab(base, token string, hc *http.Client) *gitlab {
+	if base == "" {
+		base = "https://gitlab.com"
+	}
+	return &gitlab{
+		client: client{api: base + "/api/v4", http: hc, auth: func(r *http.Request) {
+			if token != "" {
+				r.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}},
+		web: base,
+	}
+}
+
+func (g *gitlab) Kind() Kind { return GitLab }
+
+func (g *gitlab) RepoURL(owner, name string) string {
+	return g.web + "/" + owner + "/" + name
+}
+
+type gitlabProject struct {
+	Path      string `json:"path"`
+	Namespace struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+	WebURL      string   `json:"web_url"`
+	HTTPURL     string   `json:"http_url_to_repo"`
+	SSHURL      string   `json:"ssh_url_to_repo"`
+	Visibility  string   `json:"visibility"`
+	Description string   `json:"description"`
+	Topics      []string `json:"topics"`
+}
+
+func (p gitlabProject) repo() *Repo {
+	return &Repo{
+		Owner:       p.Namespace.FullPath,
+		Name:        p.Path,
+		URL:         p.WebURL,
+		CloneURL:    p.HTTPURL,
+		SSHURL:      p.SSHURL,
+		Private:     p.Visibility != "public",
+		Description: p.Description,
+		Topics:      p.Topics,
+	}
+}
+
+func (g *gitlab) Accounts(ctx context.Context) ([]string, error) {
+	var u struct {
+		Username string `json:"username"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, &u); err != nil {
+		return nil, err
+	}
+	// Developer access (30) is enough to create projects in a group.
+	var groups []struct {
+		FullPath string `json:"full_path"`
+	}
+	if err := g.do(ctx, http.MethodGet, "/groups?min_access_level=30&per_page=100", nil, &groups); err != nil {
+		return nil, err
+	}
+	accounts := []string{u.Username}
+	for _, grp := range groups {
+		accounts = append(accounts, grp.FullPath)
+	}
+	return accounts, nil
+}
+
+func (g *gitlab) CreateRepo(ctx context.Context, opts CreateOptions) (*Repo, error) {
+	in := map[string]any{
+		"name":        opts.Name,
+		"path":        opts.Name,
+		"visibility":  gitlabVisibility(opts.Private),
+		"description": opts.Description,
+	}
+	if opts.Owner != "" {
+		var ns struct {
+			ID   int    `json:"id"`
+			Kind string `json:"kind"`
+		}
+		if err := g.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, &ns); err != nil {
+			return nil, err
+		}
+		// The user's own namespace is the default; only groups need the ID.
+		if ns.Kind == "group" {
+			in["namespace_id"] = ns.ID
+		}
+	}
+	var out gitlabProject
+	if err := g.do(ctx, http.MethodPost, "/projects", in, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *gitlab) GetRepo(ctx context.Context, owner, name string) (*Repo, error) {
+	var out gitlabProject
+	if err := g.do(ctx, http.MethodGet, g.projectPath(owner, name), nil, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+func (g *gitlab) SetVisibility(ctx context.Context, owner, name string, private bool) error {
+	return g.do(ctx, http.MethodPut, g.projectPath(owner, name), map[string]any{"visibility": gitlabVisibility(private)}, nil)
+}
+
+func (g *gitlab) ArchiveRepo(ctx context.Context, owner, name string) error {
+	return g.do(ctx, http.MethodPost, g.projectPath(owner, name)+"/archive", nil, nil)
+}
+
+func (g *gitlab) DeleteRepo(ctx context.Context, owner, name string) error {
+	return g.do(ctx, http.MethodDelete, g.projectPath(owner, name), nil, nil)
+}
+
+// TransferRepo moves the project to the newOwner namespace (user or group).
+func (g *gitlab) TransferRepo(ctx context.Context, owner, name, newOwner string) (*Repo, error) {
+	var out gitlabProject
+	if err := g.do(ctx, http.MethodPut, g.projectPath(owner, name)+"/transfer", map[string]any{"namespace": newOwner}, &out); err != nil {
+		return nil, err
+	}
+	return out.repo(), nil
+}
+
+// UpdateRepo ignores meta.Homepage; GitLab projects have no homepage field.
+func (g *gitlab) UpdateRepo(ctx context.Context, owner, name string, meta Meta) error {
+	in := map[string]any{"description": meta.Description, "topics": nonNil(meta.Topics)}
+	return g.do(ctx, http.MethodPut, g.projectPath(owner, name), in, nil)
+}
+
+// gitlabIssue is an issue; GitLab's per-project number is the iid.
+type gitlabIssue struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	WebURL string `json:"web_url"`
+	State  string `json:"state"`
+}
+
+func (i gitlabIssue) issue() Issue {
+	return Issue{Number: i.IID, Title: i.Title, URL: i.WebURL, Closed: i.State == "closed"}
+}
+
+func (g *gitlab) OpenIssues(ctx context.Context, owner, name string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; page <= maxPages; page++ {
+		var batch []gitlabIssue
+		path := g.projectPath(owner, name) + "/issues?state=opened&per_page=100&page=" + strconv.Itoa(page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, i := range batch {
+			issues = append(issues, i.issue())
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+	return issues, nil
+}
+
+func (g *gitlab) CreateIssue(ctx context.Context, owner, name, title string) (*Issue, error) {
+	var out gitlabIssue
+	if err := g.do(ctx, http.MethodPost, g.projectPath(owner, name)+"/issues", map[string]any{"title": title}, &out); err != nil {
+		return nil, err
+	}
+	issue := out.issue()
+	return &issue, nil
+}
+
+func (g *gitlab) SetIssueClosed(ctx context.Context, owner, name string, number int, closed bool) error {
+	event := "reopen"
+	if closed {
+		event = "close"
+	}
+	path := g.projectPath(owner, name) + "/issues/" + strconv.Itoa(number)
+	return g.do(ctx, http.MethodPut, path, map[string]any{"state_event": event}, nil)
+}
+
+type gitlabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	WebURL       string `json:"web_url"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Draft        bool   `json:"draft"`
+}
+
+func (m gitlabMergeRequest) pull() PullRequest {
+	return PullRequest{Number: m.IID, Title: m.Title, URL: m.WebURL, Head: m.SourceBranch, Base: m.TargetBranch, Draft: m.Draft}
+}
+
+// CreatePullRequest opens a merge request; drafts use the "Draft:" title
+// prefix, which is how GitLab's API marks them.
+func (g *gitlab) CreatePullRequest(ctx context.Context, owner, name string, opts PullRequestOptions) (*PullRequest, error) {
+	title := opts.Title
+	if opts.Draft {
+		title = "Draft: " + title
+	}
+	in := map[string]any{
+		"title":         title,
+		"description":   opts.Body,
+		"source_branch": opts.Head,
+		"target_branch": opts.Base,
+	}
+	var out gitlabMergeRequest
+	if err := g.do(ctx, http.MethodPost, g.projectPath(owner, name)+"/merge_requests", in, &out); err != nil {
+		return nil, err
+	}
+	pr := out.pull()
+	return &pr, nil
+}
+
+func (g *gitlab) OpenPullRequests(ctx context.Context, owner, name string) ([]PullRequest, error) {
+	var pulls []PullRequest
+	for page := 1; page <= maxPages; page++ {
+		var batch []gitlabMergeRequest
+		path := g.projectPath(owner, name) + "/merge_requests?state=opened&per_page=100&page=" + strconv.Itoa(page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, m := range batch {
+			pulls = append(pulls, m.pull())
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+	return pulls, nil
+}
+
+// projectPath addresses a project by its URL-encoded full path.
+func (g *gitlab) projectPath(owner, name string) string {
+	return "/projects/" + strings.ReplaceAll(url.PathEscape(owner+"/"+name), "/", "%2F")
+}
+
+func gitlabVisibility(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
@@ -0,0 +1,169 @@
+package git
+
+import "context"
+
+// Git is the set of repository operations commands depend on. Exec, backed by
+// the git binary, is the default; Fake is an in-memory stand-in for tests.
+type Git interface {
+	Init(ctx context.Context, dir string) error
+	AddAll(ctx context.Context, dir string) error
+	Commit(ctx context.Context, dir, message string) error
+	Push(ctx context.Context, dir string) error
+	PushSetUpstream(ctx context.Context, dir, remote, branch string) error
+	Fetch(ctx context.Context, dir string) error
+	FastForward(ctx context.Context, dir string) error
+	IsRepo(ctx context.Context, dir string) bool
+	HasRemote(ctx context.Context, dir string) bool
+	RemoteURL(ctx context.Context, dir string) (string, error)
+	SetRemote(ctx context.Context, dir, name, url string) error
+	Clone(ctx context.Context, url, dir string, config []string) error
+	CurrentBranch(ctx context.Context, dir string) (string, error)
+	CreateBranch(ctx context.Context, dir, name string) error
+	CommitsSince(ctx context.Context, dir, base string) ([]string, error)
+	ShowFile(ctx context.Context, dir, rev, path string) ([]byte, error)
+	HasUncommitted(ctx context.Context, dir string) (bool, error)
+	Inspect(ctx context.Context, dir string) (*RepoStatus, error)
+	StagePreview(ctx context.Context, dir string) ([]StagedChange, error)
+	IsIgnored(ctx context.Context, dir, path string) (bool, error)
+	ListFiles(ctx context.Context, dir string) ([]string, error)
+	AddedLines(ctx context.Context, dir string, n int) ([]AddedLine, error)
+	HooksDir(ctx context.Context, dir string) (string, error)
+	StagedFiles(ctx context.Context, dir string) ([]string, error)
+	StagedContent(ctx context.Context, dir, path string) ([]byte, error)
+	LocalConfig(ctx context.Context, dir, key string) (string, error)
+	SetLocalConfig(ctx context.Context, dir, key, value string) error
+}
+
+// Forge is the set of hosting operations (repo creation, account selection)
+// commands depend on. GHCLI, backed by the gh binary, is the default.
+type Forge interface {
+	HasCLI(ctx context.Context) bool
+	CreateRepo(ctx context.Context, dir, name, org string, private bool) (string, error)
+	SwitchAuth(ctx context.Context, account string) error
+	AuthToken(ctx context.Context, account string) (string, error)
+	ActiveAccount(ctx context.Context) string
+	ListAuthAccounts(ctx context.Context) []string
+	IsAuthAccount(ctx context.Context, account string) bool
+}
+
+// Exec implements Git by running the git binary.
+type Exec struct{}
+
+var _ Git = Exec{}
+
+func (Exec) Init(ctx context.Context, dir string) error   { return Init(ctx, dir) }
+func (Exec) AddAll(ctx context.Context, dir string) error { return AddAll(ctx, dir) }
+func (Exec) Push(ctx context.Context, dir string) error   { return Push(ctx, dir) }
+func (Exec) IsRepo(ctx context.Context, dir string) bool  { return IsRepo(ctx, dir) }
+func (Exec) Fetch(ctx context.Context, dir string) error  { return Fetch(ctx, dir) }
+
+func (Exec) FastForward(ctx context.Context, dir string) error { return FastForward(ctx, dir) }
+
+func (Exec) HasRemote(ctx context.Context, dir string) bool { return HasRemote(ctx, dir) }
+
+func (Exec) Commit(ctx context.Context, dir, message string) error {
+	return Commit(ctx, dir, message)
+}
+
+func (Exec) PushSetUpstream(ctx context.Context, dir, remote, branch string) error {
+	return PushSetUpstream(ctx, dir, remote, branch)
+}
+
+func (Exec) RemoteURL(ctx context.Context, dir string) (string, error) {
+	return RemoteURL(ctx, dir)
+}
+
+func (Exec) SetRemote(ctx context.Context, dir, name, url string) error {
+	return SetRemote(ctx, dir, name, url)
+}
+
+func (Exec) Clone(ctx context.Context, url, dir string, config []string) error {
+	return Clone(ctx, url, dir, config)
+}
+
+func (Exec) CurrentBranch(ctx context.Context, dir string) (string, error) {
+	return CurrentBranch(ctx, dir)
+}
+
+func (Exec) CreateBranch(ctx context.Context, dir, name string) error {
+	return CreateBranch(ctx, dir, name)
+}
+
+func (Exec) CommitsSince(ctx context.Context, dir, base string) ([]string, error) {
+	return CommitsSince(ctx, dir, base)
+}
+
+func (Exec) ShowFile(ctx context.Context, dir, rev, path string) ([]byte, error) {
+	return ShowFile(ctx, dir, rev, path)
+}
+
+func (Exec) HasUncommitted(ctx context.Context, dir string) (bool, error) {
+	return HasUncommitted(ctx, dir)
+}
+
+func (Exec) Inspect(ctx context.Context, dir string) (*RepoStatus, error) {
+	return Inspect(ctx, dir)
+}
+
+func (Exec) StagePreview(ctx context.Context, dir string) ([]StagedChange, error) {
+	return StagePreview(ctx, dir)
+}
+
+func (Exec) IsIgnored(ctx context.Context, dir, path string) (bool, error) {
+	return IsIgnored(ctx, dir, path)
+}
+
+func (Exec) ListFiles(ctx context.Context, dir string) ([]string, error) {
+	return ListFiles(ctx, dir)
+}
+
+func (Exec) AddedLines(ctx context.Context, dir string, n int) ([]AddedLine, error) {
+	return AddedLines(ctx, dir, n)
+}
+
+func (Exec) HooksDir(ctx context.Context, dir string) (string, error) {
+	return HooksDir(ctx, dir)
+}
+
+func (Exec) StagedFiles(ctx context.Context, dir string) ([]string, error) {
+	return StagedFiles(ctx, dir)
+}
+
+func (Exec) StagedContent(ctx context.Context, dir, path string) ([]byte, error) {
+	return StagedContent(ctx, dir, path)
+}
+
+func (Exec) LocalConfig(ctx context.Context, dir, key string) (string, error) {
+	return LocalConfig(ctx, dir, key)
+}
+
+func (Exec) SetLocalConfig(ctx context.Context, dir, key, value string) error {
+	return SetLocalConfig(ctx, dir, key, value)
+}
+
+// GHCLI implements Forge by running the gh binary.
+type GHCLI struct{}
+
+var _ Forge = GHCLI{}
+
+func (GHCLI) HasCLI(ctx context.Context) bool { return HasGHCLI(ctx) }
+
+func (GHCLI) CreateRepo(ctx context.Context, dir, name, org string, private bool) (string, error) {
+	return CreateRepo(ctx, dir, name, org, private)
+}
+
+func (GHCLI) SwitchAuth(ctx context.Context, account string) error {
+	return SwitchAuth(ctx, account)
+}
+
+func (GHCLI) AuthToken(ctx context.Context, account string) (string, error) {
+	return AuthToken(ctx, account)
+}
+
+func (GHCLI) ActiveAccount(ctx context.Context) string { return ActiveAccount(ctx) }
+
+func (GHCLI) ListAuthAccounts(ctx context.Context) []string { return ListAuthAccounts(ctx) }
+
+func (GHCLI) IsAuthAccount(ctx context.Context, account string) bool {
+	return IsAuthAccount(ctx, account)
+}
@@ -0,0 +1,39 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// CreateBranch creates branch name at HEAD and switches to it.
+func CreateBranch(ctx context.Context, dir, name string) error {
+	return run(ctx, dir, "git", "switch", "-c", name)
+}
+
+// CommitsSince returns the subjects of the commits on HEAD that aren't on
+// base, oldest first.
+func CommitsSince(ctx context.Context, dir, base string) ([]string, error) {
+	out, err := output(ctx, dir, "git", "log", "--reverse", "--format=%s", base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// ShowFile returns path as it is at rev.
+func ShowFile(ctx context.Context, dir, rev, path string) ([]byte, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	cmd := command(ctx, dir, "git", "show", rev+":"+path)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, wrapErr(ctx, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,34 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+)
+
+// LocalConfig returns the repo-local value of key in the repository at dir,
+// or "" if it isn't set there.
+func LocalConfig(ctx context.Context, dir, key string) (string, error) {
+	out, err := output(ctx, dir, "git", "config", "--local", "--get", key)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		// Exit status 1 means the key isn't set.
+		return "", nil
+	}
+	return out, err
+}
+
+// SetLocalConfig sets key in the repository's local config, or unsets it
+// when value is empty.
+func SetLocalConfig(ctx context.Context, dir, key, value string) error {
+	if value != "" {
+		return run(ctx, dir, "git", "config", "--local", key, value)
+	}
+	err := run(ctx, dir, "git", "config", "--local", "--unset-all", key)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 5 {
+		// Exit status 5 means there was nothing to unset.
+		return nil
+	}
+	return err
+}
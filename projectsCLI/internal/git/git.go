@@ -2,101 +2,311 @@ package git
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Init initializes a git repository in the given directory.
-func Init(dir string) error {
-	return run(dir, "git", "init")
+func Init(ctx context.Context, dir string) error {
+	return run(ctx, dir, "git", "init")
 }
 
 // AddAll stages all changes.
-func AddAll(dir string) error {
-	return run(dir, "git", "add", "-A")
+func AddAll(ctx context.Context, dir string) error {
+	return run(ctx, dir, "git", "add", "-A")
 }
 
 // Commit creates a commit with the given message.
-func Commit(dir string, message string) error {
-	return run(dir, "git", "commit", "-m", message)
+func Commit(ctx context.Context, dir string, message string) error {
+	return run(ctx, dir, "git", "commit", "-m", message)
 }
 
 // Push pushes to the remote.
-func Push(dir string) error {
-	return run(dir, "git", "push")
+func Push(ctx context.Context, dir string) error {
+	return run(transfer(ctx), dir, "git", "push")
 }
 
 // PushSetUpstream pushes and sets the upstream branch.
-func PushSetUpstream(dir string, remote, branch string) error {
-	return run(dir, "git", "push", "-u", remote, branch)
+func PushSetUpstream(ctx context.Context, dir string, remote, branch string) error {
+	return run(transfer(ctx), dir, "git", "push", "-u", remote, branch)
+}
+
+// Fetch updates remote-tracking branches from all remotes.
+func Fetch(ctx context.Context, dir string) error {
+	return run(transfer(ctx), dir, "git", "fetch", "--all", "--prune")
+}
+
+// FastForward merges the upstream branch only if no merge commit is needed.
+func FastForward(ctx context.Context, dir string) error {
+	return run(ctx, dir, "git", "merge", "--ff-only", "@{upstream}")
+}
+
+// StagedChange is a path that `git add -A` would stage.
+type StagedChange struct {
+	Path    string `json:"path"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+// StagePreview lists what `git add -A` would stage, without staging it.
+// Outside a repository a throwaway git dir is used so .gitignore still applies.
+func StagePreview(ctx context.Context, dir string) ([]StagedChange, error) {
+	var out string
+	err := withScratchRepo(ctx, dir, func(prefix []string) error {
+		var err error
+		out, err = output(ctx, dir, "git", append(prefix, "-c", "core.quotePath=false", "add", "-A", "--dry-run")...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseAddDryRun(out), nil
+}
+
+func parseAddDryRun(out string) []StagedChange {
+	var changes []StagedChange
+	for _, line := range strings.Split(out, "\n") {
+		verb, quoted, ok := strings.Cut(line, " ")
+		if !ok || len(quoted) < 2 {
+			continue
+		}
+		changes = append(changes, StagedChange{
+			Path:    strings.Trim(quoted, "'"),
+			Removed: verb == "remove",
+		})
+	}
+	return changes
+}
+
+// IsIgnored reports whether .gitignore rules exclude path (relative to dir).
+// The path doesn't need to exist.
+func IsIgnored(ctx context.Context, dir, path string) (bool, error) {
+	var ignored bool
+	err := withScratchRepo(ctx, dir, func(prefix []string) error {
+		err := run(ctx, dir, "git", append(prefix, "check-ignore", "-q", "--", path)...)
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+			ignored = true
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+			// Exit status 1 means "not ignored".
+		default:
+			return err
+		}
+		return nil
+	})
+	return ignored, err
+}
+
+// withScratchRepo calls fn with the git arguments needed to treat dir as a
+// work tree: none for a repository, or a temporary --git-dir otherwise.
+func withScratchRepo(ctx context.Context, dir string, fn func(prefix []string) error) error {
+	if IsRepo(ctx, dir) {
+		return fn(nil)
+	}
+	tmp, err := os.MkdirTemp("", "projects-git-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if err := run(ctx, dir, "git", "init", "-q", "--bare", tmp); err != nil {
+		return err
+	}
+	return fn([]string{"--git-dir=" + tmp, "--work-tree=."})
 }
 
 // Status returns the git status output for a directory.
-func Status(dir string) (string, error) {
-	return output(dir, "git", "status", "--short")
+func Status(ctx context.Context, dir string) (string, error) {
+	return output(ctx, dir, "git", "status", "--short")
 }
 
 // IsRepo checks if a directory is a git repository.
-func IsRepo(dir string) bool {
-	err := run(dir, "git", "rev-parse", "--is-inside-work-tree")
+func IsRepo(ctx context.Context, dir string) bool {
+	err := run(ctx, dir, "git", "rev-parse", "--is-inside-work-tree")
 	return err == nil
 }
 
 // HasRemote checks if the repository has a remote configured.
-func HasRemote(dir string) bool {
-	out, err := output(dir, "git", "remote")
+func HasRemote(ctx context.Context, dir string) bool {
+	out, err := output(ctx, dir, "git", "remote")
 	return err == nil && strings.TrimSpace(out) != ""
 }
 
 // RemoteURL returns the URL of the first remote.
-func RemoteURL(dir string) (string, error) {
-	return output(dir, "git", "remote", "get-url", "origin")
+func RemoteURL(ctx context.Context, dir string) (string, error) {
+	return output(ctx, dir, "git", "remote", "get-url", "origin")
+}
+
+// SetRemote points the named remote at url, adding it if it doesn't exist.
+func SetRemote(ctx context.Context, dir, name, url string) error {
+	if _, err := output(ctx, dir, "git", "remote", "get-url", name); err == nil {
+		return run(ctx, dir, "git", "remote", "set-url", name, url)
+	}
+	return run(ctx, dir, "git", "remote", "add", name, url)
+}
+
+// Clone clones url into dir, which must not exist yet. config holds
+// "key=value" settings written to the new repo's local config before the
+// first fetch, so e.g. core.sshCommand applies to the clone itself.
+func Clone(ctx context.Context, url, dir string, config []string) error {
+	args := []string{"clone"}
+	for _, c := range config {
+		args = append(args, "--config", c)
+	}
+	args = append(args, "--", url, dir)
+	return run(transfer(ctx), filepath.Dir(dir), "git", args...)
 }
 
 // CurrentBranch returns the current branch name.
-func CurrentBranch(dir string) (string, error) {
-	return output(dir, "git", "branch", "--show-current")
+func CurrentBranch(ctx context.Context, dir string) (string, error) {
+	return output(ctx, dir, "git", "branch", "--show-current")
 }
 
 // HasUncommitted checks if there are uncommitted changes.
-func HasUncommitted(dir string) (bool, error) {
-	out, err := Status(dir)
+func HasUncommitted(ctx context.Context, dir string) (bool, error) {
+	out, err := Status(ctx, dir)
 	if err != nil {
 		return false, err
 	}
 	return strings.TrimSpace(out) != "", nil
 }
 
-// run executes a command in the given directory.
-func run(dir string, name string, args ...string) error {
-	cmd := exec.Command(name, args...)
+// CommandTimeout bounds each git/gh subprocess except network transfers
+// (push, fetch, clone), whose time depends on the repo's size. Zero means no
+// limit beyond the caller's context.
+var CommandTimeout = 2 * time.Minute
+
+type transferKey struct{}
+
+// transfer marks ctx as a network transfer, exempt from CommandTimeout.
+func transfer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transferKey{}, true)
+}
+
+type noPromptKey struct{}
+
+// WithNoPrompt marks ctx so subprocesses fail instead of waiting for a
+// credential prompt nobody can answer (non-interactive or parallel runs).
+func WithNoPrompt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noPromptKey{}, true)
+}
+
+func noPrompt(ctx context.Context) bool {
+	v, _ := ctx.Value(noPromptKey{}).(bool)
+	return v
+}
+
+type envKey struct{}
+
+// WithEnv adds "KEY=value" settings to the environment of subprocesses
+// started with ctx, on top of any added by an outer WithEnv.
+func WithEnv(ctx context.Context, env ...string) context.Context {
+	prev, _ := ctx.Value(envKey{}).([]string)
+	return context.WithValue(ctx, envKey{}, append(slices.Clip(prev), env...))
+}
+
+type ghTokenKey struct{}
+
+// WithGHToken scopes ctx to a GitHub token: gh and git subprocesses started
+// with it get GH_TOKEN (replacing any inherited GH_TOKEN or GITHUB_TOKEN), so
+// they act as that token's account without changing gh's globally active
+// account. git picks it up through gh's credential helper. An empty token
+// just clears the inherited ones.
+func WithGHToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, ghTokenKey{}, token)
+}
+
+// GHToken returns the token set by WithGHToken, or "".
+func GHToken(ctx context.Context) string {
+	v, _ := ctx.Value(ghTokenKey{}).(string)
+	return v
+}
+
+// withTimeout applies CommandTimeout to ctx unless it is a transfer.
+func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if isTransfer, _ := ctx.Value(transferKey{}).(bool); CommandTimeout > 0 && !isTransfer {
+		return context.WithTimeout(ctx, CommandTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// command builds a subprocess that is killed when ctx ends.
+func command(ctx context.Context, dir string, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
+	cmd.WaitDelay = 5 * time.Second
+	if noPrompt(ctx) {
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never", "GH_PROMPT_DISABLED=1")
+	}
+	if extra, _ := ctx.Value(envKey{}).([]string); len(extra) > 0 {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, extra...)
+	}
+	if token, scoped := ctx.Value(ghTokenKey{}).(string); scoped {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		// Drop inherited tokens so only the scoped one (if any) applies.
+		env := cmd.Env[:0:0]
+		for _, kv := range cmd.Env {
+			if !strings.HasPrefix(kv, "GH_TOKEN=") && !strings.HasPrefix(kv, "GITHUB_TOKEN=") {
+				env = append(env, kv)
+			}
+		}
+		if token != "" {
+			env = append(env, "GH_TOKEN="+token)
+		}
+		cmd.Env = env
+	}
+	return cmd
+}
+
+// wrapErr explains why a command failed: cancellation, timeout, or its stderr.
+func wrapErr(ctx context.Context, err error, stderr string) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf("timed out after %s", CommandTimeout)
+	case context.Canceled:
+		return ctx.Err()
+	}
+	msg := strings.TrimSpace(stderr)
+	if msg != "" {
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return err
+}
+
+// run executes a command in the given directory.
+func run(ctx context.Context, dir string, name string, args ...string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	cmd := command(ctx, dir, name, args...)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		msg := strings.TrimSpace(stderr.String())
-		if msg != "" {
-			return fmt.Errorf("%s: %s", err, msg)
-		}
-		return err
+		return wrapErr(ctx, err, stderr.String())
 	}
 	return nil
 }
 
 // output executes a command and returns its stdout.
-func output(dir string, name string, args ...string) (string, error) {
-	cmd := exec.Command(name, args...)
-	cmd.Dir = dir
+func output(ctx context.Context, dir string, name string, args ...string) (string, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	cmd := command(ctx, dir, name, args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		msg := strings.TrimSpace(stderr.String())
-		if msg != "" {
-			return "", fmt.Errorf("%s: %s", err, msg)
-		}
-		return "", err
+		return "", wrapErr(ctx, err, stderr.String())
 	}
 	return strings.TrimSpace(stdout.String()), nil
 }
@@ -0,0 +1,261 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseAddDryRun(t *testing.T) {
+	got := parseAddDryRun("add 'docs/new file.md'\nremove 'old.txt'\n")
+	want := []StagedChange{{Path: "docs/new file.md"}, {Path: "old.txt", Removed: true}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("parseAddDryRun = %+v, want %+v", got, want)
+	}
+}
+
+// TestStagePreviewOutsideRepo checks the scratch git dir fallback against the
+// real git binary.
+func TestStagePreviewOutsideRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		".gitignore":   "private/\n",
+		"README.md":    "hi",
+		"private/.env": "TOKEN=x",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	changes, err := StagePreview(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Path != ".gitignore" || changes[1].Path != "README.md" {
+		t.Errorf("StagePreview = %+v", changes)
+	}
+
+	if ignored, err := IsIgnored(ctx, dir, "private/anything"); err != nil || !ignored {
+		t.Errorf("IsIgnored(private/anything) = %v, %v", ignored, err)
+	}
+	if ignored, err := IsIgnored(ctx, dir, "README.md"); err != nil || ignored {
+		t.Errorf("IsIgnored(README.md) = %v, %v", ignored, err)
+	}
+	if IsRepo(ctx, dir) {
+		t.Error("preview left a repository behind")
+	}
+}
+
+func TestParseLogPatch(t *testing.T) {
+	out := "\x00abc123\n\n" +
+		"diff --git a/app.env b/app.env\n" +
+		"--- a/app.env\n" +
+		"+++ b/app.env\n" +
+		"@@ -2 +2,2 @@\n" +
+		"-OLD=1\n" +
+		"+NEW=1\n" +
+		"+--- not a header\n" +
+		"\\ No newline at end of file\n" +
+		"diff --git a/gone.txt b/gone.txt\n" +
+		"--- a/gone.txt\n" +
+		"+++ /dev/null\n" +
+		"@@ -1 +0,0 @@\n" +
+		"-bye\n"
+	got := parseLogPatch(out)
+	want := []AddedLine{
+		{Commit: "abc123", Path: "app.env", Line: 2, Text: "NEW=1"},
+		{Commit: "abc123", Path: "app.env", Line: 3, Text: "--- not a header"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("parseLogPatch = %+v, want %+v", got, want)
+	}
+}
+
+// TestAddedLines runs AddedLines against a real repository.
+func TestAddedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	if lines, err := AddedLines(ctx, dir, 10); err != nil || lines != nil {
+		t.Fatalf("AddedLines outside a repo = %v, %v", lines, err)
+	}
+	if err := Init(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	commit := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "my notes.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddAll(ctx, dir); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command("git", "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-qm", "x")
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("commit: %v\n%s", err, out)
+		}
+	}
+	commit("one\n")
+	commit("one\ntwo\n")
+
+	lines, err := AddedLines(ctx, dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 1 || lines[0].Path != "my notes.md" || lines[0].Line != 2 || lines[0].Text != "two" {
+		t.Errorf("AddedLines(1) = %+v", lines)
+	}
+	if lines, _ := AddedLines(ctx, dir, 5); len(lines) != 2 {
+		t.Errorf("AddedLines(5) = %+v, want both commits", lines)
+	}
+}
+
+func TestLocalConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := Init(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := LocalConfig(ctx, dir, "user.email"); err != nil || v != "" {
+		t.Fatalf("unset key = %q, %v", v, err)
+	}
+	if err := SetLocalConfig(ctx, dir, "core.sshCommand", "ssh -i ~/.ssh/id_work"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := LocalConfig(ctx, dir, "core.sshCommand"); v != "ssh -i ~/.ssh/id_work" {
+		t.Errorf("core.sshCommand = %q", v)
+	}
+	for i := 0; i < 2; i++ { // unsetting twice is fine
+		if err := SetLocalConfig(ctx, dir, "core.sshCommand", ""); err != nil {
+			t.Fatalf("unset #%d: %v", i+1, err)
+		}
+	}
+}
+
+func TestBranchHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := Init(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+	commit := func(content, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "TODO.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddAll(ctx, dir); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command("git", "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-qm", message)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("commit: %v\n%s", err, out)
+		}
+	}
+	commit("- [ ] a\n", "Start")
+	base, err := CurrentBranch(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CreateBranch(ctx, dir, "topic"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := CommitsSince(ctx, dir, base); got != nil {
+		t.Errorf("CommitsSince on a fresh branch = %q", got)
+	}
+	commit("- [x] a\n", "Tick a")
+	commit("- [x] a\n- [ ] b\n", "Add b")
+
+	got, err := CommitsSince(ctx, dir, base)
+	if err != nil || len(got) != 2 || got[0] != "Tick a" || got[1] != "Add b" {
+		t.Errorf("CommitsSince = %q, %v", got, err)
+	}
+	if data, err := ShowFile(ctx, dir, base, "TODO.md"); err != nil || string(data) != "- [ ] a\n" {
+		t.Errorf("ShowFile(%s) = %q, %v", base, data, err)
+	}
+	if _, err := ShowFile(ctx, dir, base, "missing.md"); err == nil {
+		t.Error("ShowFile of a missing path succeeded")
+	}
+}
+
+// TestClone clones from a local bare repository.
+func TestClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	src := t.TempDir()
+	if err := Init(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "README.md"), []byte("# Demo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddAll(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+	bare := filepath.Join(t.TempDir(), "demo.git")
+	for _, args := range [][]string{
+		{"-C", src, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-qm", "Start"},
+		{"clone", "-q", "--bare", src, bare},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	dir := filepath.Join(t.TempDir(), "demo")
+	if err := Clone(ctx, bare, dir, []string{"user.name=Cloner"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "README.md")); err != nil || string(data) != "# Demo\n" {
+		t.Errorf("README.md = %q, %v", data, err)
+	}
+	if url, err := RemoteURL(ctx, dir); err != nil || url != bare {
+		t.Errorf("origin = %q, %v", url, err)
+	}
+	if name, err := LocalConfig(ctx, dir, "user.name"); err != nil || name != "Cloner" {
+		t.Errorf("user.name = %q, %v", name, err)
+	}
+	if err := Clone(ctx, bare, dir, nil); err == nil {
+		t.Error("cloning into an existing directory succeeded")
+	}
+}
+
+func TestTransferSkipsTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	timed, cancel := withTimeout(ctx)
+	defer cancel()
+	if _, ok := timed.Deadline(); !ok {
+		t.Error("plain command has no deadline")
+	}
+
+	untimed, cancel := withTimeout(transfer(ctx))
+	defer cancel()
+	if _, ok := untimed.Deadline(); ok {
+		t.Error("transfer has a deadline")
+	}
+}
@@ -1,12 +1,15 @@
 package git
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
 // CreateRepo creates a GitHub repository using the gh CLI.
-func CreateRepo(dir, name, org string, private bool) (string, error) {
+func CreateRepo(ctx context.Context, dir, name, org string, private bool) (string, error) {
 	args := []string{"repo", "create"}
 
 	repoName := name
@@ -23,7 +26,7 @@ func CreateRepo(dir, name, org string, private bool) (string, error) {
 
 	args = append(args, "--source", dir, "--push")
 
-	out, err := output(dir, "gh", args...)
+	out, err := output(transfer(ctx), dir, "gh", args...)
 	if err != nil {
 		return "", fmt.Errorf("gh repo create: %w", err)
 	}
@@ -31,20 +34,57 @@ func CreateRepo(dir, name, org string, private bool) (string, error) {
 }
 
 // HasGHCLI checks if the gh CLI is available.
-func HasGHCLI() bool {
-	_, err := output(".", "gh", "version")
+func HasGHCLI(ctx context.Context) bool {
+	_, err := output(ctx, ".", "gh", "version")
 	return err == nil
 }
 
-// SwitchAuth switches the active GitHub CLI account to the given user.
-func SwitchAuth(account string) error {
-	return run(".", "gh", "auth", "switch", "--user", account)
+// SwitchAuth switches the active GitHub CLI account to the given user. This
+// is global and outlives the process; prefer AuthToken with WithGHToken.
+func SwitchAuth(ctx context.Context, account string) error {
+	return run(ctx, ".", "gh", "auth", "switch", "--user", account)
+}
+
+// AuthToken returns the token gh stores for account, without making it the
+// active account. It needs gh 2.40 or later.
+func AuthToken(ctx context.Context, account string) (string, error) {
+	// gh returns an inherited GH_TOKEN as-is, whatever --user says.
+	ctx = WithGHToken(ctx, "")
+	out, err := output(ctx, ".", "gh", "auth", "token", "--user", account)
+	if err != nil {
+		return "", fmt.Errorf("gh auth token: %w", err)
+	}
+	if out == "" {
+		return "", fmt.Errorf("gh auth token: no token for %s", account)
+	}
+	return out, nil
+}
+
+var activeAccountRe = regexp.MustCompile(`account (\S+)`)
+
+// ActiveAccount returns the account gh currently acts as on github.com, or
+// "" if it can't tell.
+func ActiveAccount(ctx context.Context) string {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	// gh has printed auth status to stderr or stdout depending on version.
+	cmd := command(ctx, ".", "gh", "auth", "status", "--active", "--hostname", "github.com")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+	if m := activeAccountRe.FindStringSubmatch(out.String()); m != nil {
+		return m[1]
+	}
+	return ""
 }
 
 // ListAuthAccounts returns the GitHub usernames authenticated via gh auth.
 // Returns nil if gh is not installed or no accounts are logged in.
-func ListAuthAccounts() []string {
-	out, err := output(".", "gh", "auth", "status", "--format", "{{range .}}{{.account}}\n{{end}}")
+func ListAuthAccounts(ctx context.Context) []string {
+	out, err := output(ctx, ".", "gh", "auth", "status", "--format", "{{range .}}{{.account}}\n{{end}}")
 	if err != nil {
 		return nil
 	}
@@ -59,8 +99,8 @@ func ListAuthAccounts() []string {
 }
 
 // IsAuthAccount checks if the given account is authenticated via gh auth.
-func IsAuthAccount(account string) bool {
-	for _, a := range ListAuthAccounts() {
+func IsAuthAccount(ctx context.Context, account string) bool {
+	for _, a := range ListAuthAccounts(ctx) {
 		if strings.EqualFold(a, account) {
 			return true
 		}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeGH is a stand-in gh that logs each call with the GH_TOKEN it saw and
+// keeps the active account in a file.
+const fakeGH = `#!/bin/sh
+echo "GH_TOKEN=$GH_TOKEN $*" >> "$FAKE_GH_DIR/log"
+case "$1 $2" in
+"auth token")
+	[ -n "$FAKE_GH_NO_TOKEN" ] && { echo "unknown flag: --user" >&2; exit 1; }
+	echo "tok-$4" ;;
+"auth status")
+	echo "github.com" >&2
+	echo "  ✓ Logged in to github.com account $(cat "$FAKE_GH_DIR/active") (keyring)" >&2 ;;
+"auth switch")
+	echo "$4" > "$FAKE_GH_DIR/active" ;;
+esac
+`
+
+// installFakeGH puts fakeGH first on PATH, logged in as active.
+func installFakeGH(t *testing.T, active string) (dir string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gh is a shell script")
+	}
+	dir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "gh"), []byte(fakeGH), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "active"), []byte(active+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_GH_DIR", dir)
+	return dir
+}
+
+func readGHLog(t *testing.T, dir string) []string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "log"))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestAuthTokenScoping(t *testing.T) {
+	dir := installFakeGH(t, "me")
+	t.Setenv("GH_TOKEN", "inherited")
+	ctx := context.Background()
+
+	token, err := AuthToken(ctx, "work")
+	if err != nil || token != "tok-work" {
+		t.Fatalf("AuthToken = %q, %v", token, err)
+	}
+	if err := run(WithGHToken(ctx, token), ".", "gh", "repo", "view"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"GH_TOKEN= auth token --user work", "GH_TOKEN=tok-work repo view"}
+	if got := readGHLog(t, dir); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("gh calls = %q, want %q", got, want)
+	}
+	if got := ActiveAccount(ctx); got != "me" {
+		t.Errorf("ActiveAccount = %q, want me (unchanged)", got)
+	}
+}
+
+func TestActiveAccountAfterSwitch(t *testing.T) {
+	installFakeGH(t, "me")
+	t.Setenv("FAKE_GH_NO_TOKEN", "1")
+	ctx := context.Background()
+
+	if _, err := AuthToken(ctx, "work"); err == nil {
+		t.Fatal("AuthToken succeeded on a gh without --user")
+	}
+	if err := SwitchAuth(ctx, "work"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ActiveAccount(ctx); got != "work" {
+		t.Errorf("ActiveAccount = %q, want work", got)
+	}
+}
@@ -0,0 +1,793 @@
+// Package gittest provides in-memory implementations of git.Git and git.Forge
+// for tests.
+package gittest
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackmorganxyz/projectsCLI/internal/git"
+)
+
+// Fake is an in-memory git.Git for tests. Repository state (index, commits,
+// remote) lives in memory while file contents are read from disk, so commands
+// that scaffold or edit files behave as they would against real git.
+//
+// Repositories are identified by their directory's inode rather than its
+// path, so a project that is moved with os.Rename keeps its history.
+type Fake struct {
+	mu    sync.Mutex
+	repos []*FakeRepo
+}
+
+// FakeRepo is the state of one repository in a Fake.
+type FakeRepo struct {
+	Branch   string
+	Remote   string // origin URL, empty when no remote is configured
+	Upstream string // e.g. "origin/main" once pushed with -u
+	Commits  []string
+	Pushed   int // number of local commits the remote has
+	// Incoming are commits on the remote the local repo doesn't have yet;
+	// Fetch makes them visible as "behind", FastForward applies them.
+	Incoming []string
+	// PushToken is the scoped GH_TOKEN (see git.WithGHToken) of the last push.
+	PushToken string
+	// CloneToken is the scoped GH_TOKEN the repo was cloned with.
+	CloneToken string
+	// Config is the repo-local git config, keyed as git does (user.email).
+	Config map[string]string
+
+	// bases records, for each branch left by CreateBranch, its commits
+	// and committed files at that point. The fake keeps one linear history.
+	bases map[string]fakeBase
+
+	fetched   int // how many Incoming commits have been fetched
+	added     [][]git.AddedLine
+	info      os.FileInfo
+	index     map[string]string
+	committed map[string]string
+}
+
+// NewFake returns an empty Fake.
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+var _ git.Git = (*Fake)(nil)
+
+// Repo returns the repository at dir, or nil if dir isn't one.
+func (f *Fake) Repo(dir string) *FakeRepo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lookup(dir)
+}
+
+func (f *Fake) lookup(dir string) *FakeRepo {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil
+	}
+	for _, r := range f.repos {
+		if os.SameFile(r.info, info) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (f *Fake) repo(dir string) (*FakeRepo, error) {
+	r := f.lookup(dir)
+	if r == nil {
+		return nil, fmt.Errorf("fatal: not a git repository: %s", dir)
+	}
+	return r, nil
+}
+
+func (f *Fake) Init(_ context.Context, dir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lookup(dir) != nil {
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	f.repos = append(f.repos, &FakeRepo{
+		Branch:    "main",
+		Config:    map[string]string{},
+		info:      info,
+		index:     map[string]string{},
+		committed: map[string]string{},
+	})
+	return nil
+}
+
+func (f *Fake) AddAll(_ context.Context, dir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	tree, err := readTree(dir)
+	if err != nil {
+		return err
+	}
+	r.index = tree
+	return nil
+}
+
+func (f *Fake) Commit(_ context.Context, dir, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if countChanged(r.committed, r.index) == 0 {
+		return fmt.Errorf("nothing to commit, working tree clean")
+	}
+	r.Commits = append(r.Commits, message)
+	r.added = append(r.added, addedLines(fmt.Sprintf("%040x", len(r.Commits)), r.committed, r.index))
+	r.committed = copyTree(r.index)
+	return nil
+}
+
+func (f *Fake) Push(ctx context.Context, dir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if r.Upstream == "" {
+		return fmt.Errorf("fatal: the current branch %s has no upstream branch", r.Branch)
+	}
+	r.Pushed = len(r.Commits)
+	r.PushToken = git.GHToken(ctx)
+	return nil
+}
+
+func (f *Fake) PushSetUpstream(ctx context.Context, dir, remote, branch string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if remote != "origin" || r.Remote == "" {
+		return fmt.Errorf("fatal: '%s' does not appear to be a git repository", remote)
+	}
+	if len(r.Commits) == 0 {
+		return fmt.Errorf("error: src refspec %s does not match any", branch)
+	}
+	r.Upstream = remote + "/" + branch
+	r.Pushed = len(r.Commits)
+	r.PushToken = git.GHToken(ctx)
+	return nil
+}
+
+func (f *Fake) Fetch(_ context.Context, dir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if r.Remote == "" {
+		return fmt.Errorf("fatal: no remote configured")
+	}
+	r.fetched = len(r.Incoming)
+	return nil
+}
+
+func (f *Fake) FastForward(_ context.Context, dir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if r.Upstream == "" {
+		return fmt.Errorf("fatal: no upstream configured for branch '%s'", r.Branch)
+	}
+	if r.fetched == 0 {
+		return nil
+	}
+	if len(r.Commits) > r.Pushed {
+		return fmt.Errorf("fatal: not possible to fast-forward, aborting")
+	}
+	r.Commits = append(r.Commits, r.Incoming[:r.fetched]...)
+	r.Incoming = r.Incoming[r.fetched:]
+	r.Pushed = len(r.Commits)
+	r.fetched = 0
+	return nil
+}
+
+func (f *Fake) IsRepo(_ context.Context, dir string) bool {
+	return f.Repo(dir) != nil
+}
+
+func (f *Fake) HasRemote(_ context.Context, dir string) bool {
+	r := f.Repo(dir)
+	return r != nil && r.Remote != ""
+}
+
+func (f *Fake) RemoteURL(_ context.Context, dir string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return "", err
+	}
+	if r.Remote == "" {
+		return "", fmt.Errorf("error: No such remote 'origin'")
+	}
+	return r.Remote, nil
+}
+
+func (f *Fake) SetRemote(_ context.Context, dir, name, url string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if name != "origin" {
+		return fmt.Errorf("fake git only tracks origin, not %q", name)
+	}
+	r.Remote = url
+	return nil
+}
+
+// Clone copies the repo at url, which must be the directory of another
+// repository in f, into dir: its committed files are written out and its
+// history becomes dir's, already pushed to origin.
+func (f *Fake) Clone(ctx context.Context, url, dir string, config []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	src := f.lookup(url)
+	if src == nil {
+		// A repo that was pushed can be cloned from its remote.
+		for _, r := range f.repos {
+			if r.Remote == url && r.Pushed > 0 {
+				src = r
+				break
+			}
+		}
+	}
+	if src == nil {
+		return fmt.Errorf("fatal: repository '%s' does not exist", url)
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("fatal: destination path '%s' already exists", dir)
+	}
+	for name, content := range src.committed {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	cfg := map[string]string{}
+	for _, c := range config {
+		if k, v, ok := strings.Cut(c, "="); ok {
+			cfg[k] = v
+		}
+	}
+	f.repos = append(f.repos, &FakeRepo{
+		Branch:     src.Branch,
+		Remote:     url,
+		Upstream:   "origin/" + src.Branch,
+		Commits:    append([]string(nil), src.Commits...),
+		Pushed:     len(src.Commits),
+		CloneToken: git.GHToken(ctx),
+		Config:     cfg,
+		info:       info,
+		index:      copyTree(src.committed),
+		committed:  copyTree(src.committed),
+	})
+	return nil
+}
+
+func (f *Fake) CurrentBranch(_ context.Context, dir string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return "", err
+	}
+	return r.Branch, nil
+}
+
+// fakeBase is a branch as it was when another was created from it.
+type fakeBase struct {
+	commits int
+	files   map[string]string
+}
+
+func (f *Fake) CreateBranch(_ context.Context, dir, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.bases[name]; ok || name == r.Branch {
+		return fmt.Errorf("fatal: a branch named '%s' already exists", name)
+	}
+	if r.bases == nil {
+		r.bases = map[string]fakeBase{}
+	}
+	r.bases[r.Branch] = fakeBase{commits: len(r.Commits), files: copyTree(r.committed)}
+	r.Branch = name
+	r.Upstream = ""
+	return nil
+}
+
+func (f *Fake) CommitsSince(_ context.Context, dir, base string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := r.bases[base]
+	if !ok {
+		return nil, fmt.Errorf("fatal: ambiguous argument '%s..HEAD': unknown revision", base)
+	}
+	return append([]string(nil), r.Commits[b.commits:]...), nil
+}
+
+func (f *Fake) ShowFile(_ context.Context, dir, rev, path string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := r.committed
+	if rev != "HEAD" && rev != r.Branch {
+		b, ok := r.bases[rev]
+		if !ok {
+			return nil, fmt.Errorf("fatal: invalid object name '%s'", rev)
+		}
+		files = b.files
+	}
+	content, ok := files[path]
+	if !ok {
+		return nil, fmt.Errorf("fatal: path '%s' does not exist in '%s'", path, rev)
+	}
+	return []byte(content), nil
+}
+
+func (f *Fake) HasUncommitted(ctx context.Context, dir string) (bool, error) {
+	st, err := f.Inspect(ctx, dir)
+	if err != nil {
+		return false, err
+	}
+	return st.Dirty(), nil
+}
+
+func (f *Fake) Inspect(_ context.Context, dir string) (*git.RepoStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := readTree(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &git.RepoStatus{
+		Branch:   r.Branch,
+		Upstream: r.Upstream,
+		Staged:   countChanged(r.committed, r.index),
+	}
+	for name, content := range tree {
+		indexed, ok := r.index[name]
+		switch {
+		case !ok:
+			st.Untracked++
+		case indexed != content:
+			st.Unstaged++
+		}
+	}
+	for name := range r.index {
+		if _, ok := tree[name]; !ok {
+			st.Unstaged++
+		}
+	}
+	if r.Upstream != "" {
+		st.Ahead = len(r.Commits) - r.Pushed
+		st.Behind = r.fetched
+	}
+	if n := len(r.Commits); n > 0 {
+		st.LastCommit = &git.CommitInfo{
+			Hash:    fmt.Sprintf("%040x", n),
+			Author:  "Fake",
+			Time:    time.Now().UTC(),
+			Subject: r.Commits[n-1],
+		}
+	}
+	return st, nil
+}
+
+func (f *Fake) StagePreview(_ context.Context, dir string) ([]git.StagedChange, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index := map[string]string{}
+	if r := f.lookup(dir); r != nil {
+		index = r.index
+	}
+	tree, err := readTree(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []git.StagedChange
+	for name, content := range tree {
+		if old, ok := index[name]; !ok || old != content {
+			changes = append(changes, git.StagedChange{Path: name})
+		}
+	}
+	for name := range index {
+		if _, ok := tree[name]; !ok {
+			changes = append(changes, git.StagedChange{Path: name, Removed: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func (f *Fake) IsIgnored(_ context.Context, dir, path string) (bool, error) {
+	return loadIgnore(dir)(filepath.ToSlash(path), false), nil
+}
+
+func (f *Fake) ListFiles(_ context.Context, dir string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(r.index))
+	for name := range r.index {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (f *Fake) AddedLines(_ context.Context, dir string, n int) ([]git.AddedLine, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	var lines []git.AddedLine
+	for i := len(r.added) - 1; i >= 0 && i >= len(r.added)-n; i-- {
+		lines = append(lines, r.added[i]...)
+	}
+	return lines, nil
+}
+
+// HooksDir returns dir/.git/hooks, creating it on disk.
+func (f *Fake) HooksDir(_ context.Context, dir string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.repo(dir); err != nil {
+		return "", err
+	}
+	hooks := filepath.Join(dir, ".git", "hooks")
+	return hooks, os.MkdirAll(hooks, 0755)
+}
+
+func (f *Fake) StagedFiles(_ context.Context, dir string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for name, content := range r.index {
+		if old, ok := r.committed[name]; !ok || old != content {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (f *Fake) StagedContent(_ context.Context, dir, path string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := r.index[path]
+	if !ok {
+		return nil, fmt.Errorf("fatal: path '%s' is not in the index", path)
+	}
+	return []byte(content), nil
+}
+
+func (f *Fake) LocalConfig(_ context.Context, dir, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return "", err
+	}
+	return r.Config[key], nil
+}
+
+func (f *Fake) SetLocalConfig(_ context.Context, dir, key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(r.Config, key)
+	} else {
+		r.Config[key] = value
+	}
+	return nil
+}
+
+// setRemote points origin at url, as gh repo create --source does.
+func (f *Fake) setRemote(dir, url string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	r, err := f.repo(dir)
+	if err != nil {
+		return err
+	}
+	r.Remote = url
+	return nil
+}
+
+// FakeForge is an in-memory git.Forge for tests. Repos it creates are wired up
+// as the origin remote of the matching Fake repository and pushed.
+type FakeForge struct {
+	Git      *Fake
+	Accounts []string // authenticated accounts
+	Active   string   // account gh would act as
+	NoCLI    bool     // simulate gh not being installed
+	NoToken  bool     // simulate a gh without `auth token --user`
+	Created  []string // owner/name of every repo created
+	// CreatedAs is the account each repo in Created was created as: the
+	// scoped token's account, or Active.
+	CreatedAs []string
+	// Public lists the repos in Created that were created public.
+	Public []string
+}
+
+var _ git.Forge = (*FakeForge)(nil)
+
+func (f *FakeForge) HasCLI(context.Context) bool { return !f.NoCLI }
+
+func (f *FakeForge) CreateRepo(ctx context.Context, dir, name, org string, private bool) (string, error) {
+	as := f.Active
+	if account, ok := strings.CutPrefix(git.GHToken(ctx), "token-"); ok {
+		as = account
+	}
+	owner := org
+	if owner == "" {
+		owner = as
+	}
+	full := strings.TrimPrefix(owner+"/"+name, "/")
+	for _, c := range f.Created {
+		if c == full {
+			return "", fmt.Errorf("gh repo create: repository %s already exists", full)
+		}
+	}
+
+	url := "https://github.com/" + full
+	if f.Git != nil {
+		if err := f.Git.setRemote(dir, url); err != nil {
+			return "", fmt.Errorf("gh repo create: %w", err)
+		}
+		branch, _ := f.Git.CurrentBranch(ctx, dir)
+		if err := f.Git.PushSetUpstream(ctx, dir, "origin", branch); err != nil {
+			return "", fmt.Errorf("gh repo create: %w", err)
+		}
+	}
+	f.Created = append(f.Created, full)
+	f.CreatedAs = append(f.CreatedAs, as)
+	if !private {
+		f.Public = append(f.Public, full)
+	}
+	return url, nil
+}
+
+// AuthToken returns "token-<account>", which CreateRepo recognises when the
+// token is scoped with git.WithGHToken.
+func (f *FakeForge) AuthToken(ctx context.Context, account string) (string, error) {
+	if f.NoCLI || f.NoToken {
+		return "", fmt.Errorf("gh auth token: unknown flag: --user")
+	}
+	if !f.IsAuthAccount(ctx, account) {
+		return "", fmt.Errorf("gh auth token: no oauth token found for github.com account %s", account)
+	}
+	return "token-" + account, nil
+}
+
+func (f *FakeForge) ActiveAccount(context.Context) string { return f.Active }
+
+func (f *FakeForge) SwitchAuth(ctx context.Context, account string) error {
+	if !f.IsAuthAccount(ctx, account) {
+		return fmt.Errorf("not logged in to github.com account %s", account)
+	}
+	f.Active = account
+	return nil
+}
+
+func (f *FakeForge) ListAuthAccounts(context.Context) []string {
+	if f.NoCLI {
+		return nil
+	}
+	return f.Accounts
+}
+
+func (f *FakeForge) IsAuthAccount(ctx context.Context, account string) bool {
+	for _, a := range f.ListAuthAccounts(ctx) {
+		if strings.EqualFold(a, account) {
+			return true
+		}
+	}
+	return false
+}
+
+// readTree returns the contents of every regular, non-ignored file under
+// dir, keyed by slash-separated relative path.
+func readTree(dir string) (map[string]string, error) {
+	ignored := loadIgnore(dir)
+	tree := map[string]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if d.Name() == ".git" || ignored(rel, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || ignored(rel, false) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		tree[rel] = string(data)
+		return nil
+	})
+	return tree, err
+}
+
+// loadIgnore returns a matcher for the top-level .gitignore in dir. It
+// understands plain names, globs, anchored paths and trailing-slash directory
+// patterns; negation and nested .gitignore files are not supported.
+func loadIgnore(dir string) func(rel string, isDir bool) bool {
+	data, _ := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "!") {
+			patterns = append(patterns, line)
+		}
+	}
+
+	matchOne := func(pattern, rel string, isDir bool) bool {
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.TrimSuffix(pattern, "/")
+		if dirOnly && !isDir {
+			return false
+		}
+		if strings.Contains(pattern, "/") {
+			ok, _ := path.Match(strings.TrimPrefix(pattern, "/"), rel)
+			return ok
+		}
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+
+	return func(rel string, isDir bool) bool {
+		// A path is ignored if it or any parent directory matches.
+		parts := strings.Split(rel, "/")
+		for i := range parts {
+			prefix := strings.Join(parts[:i+1], "/")
+			prefixIsDir := isDir || i < len(parts)-1
+			for _, p := range patterns {
+				if matchOne(p, prefix, prefixIsDir) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// countChanged returns how many paths differ between two trees.
+func countChanged(from, to map[string]string) int {
+	n := 0
+	for name, content := range to {
+		if old, ok := from[name]; !ok || old != content {
+			n++
+		}
+	}
+	for name := range from {
+		if _, ok := to[name]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// addedLines approximates a diff: every line of a changed file that didn't
+// appear in its previous version counts as added.
+func addedLines(commit string, from, to map[string]string) []git.AddedLine {
+	var lines []git.AddedLine
+	for name, content := range to {
+		old, ok := from[name]
+		if ok && old == content {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, l := range strings.Split(old, "\n") {
+			seen[l] = true
+		}
+		for i, l := range strings.Split(content, "\n") {
+			if !seen[l] {
+				lines = append(lines, git.AddedLine{Commit: commit, Path: name, Line: i + 1, Text: l})
+			}
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Path != lines[j].Path {
+			return lines[i].Path < lines[j].Path
+		}
+		return lines[i].Line < lines[j].Line
+	})
+	return lines
+}
+
+func copyTree(tree map[string]string) map[string]string {
+	out := make(map[string]string, len(tree))
+	for k, v := range tree {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AddedLine is a line introduced by a commit.
+type AddedLine struct {
+	Commit string
+	Path   string
+	Line   int
+	Text   string
+}
+
+// ListFiles returns the tracked and staged files in the repository at dir.
+func ListFiles(ctx context.Context, dir string) ([]string, error) {
+	out, err := output(ctx, dir, "git", "-c", "core.quotePath=false", "ls-files", "-z", "--cached")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// AddedLines returns the lines added by the last n commits on HEAD. A
+// repository with no commits yields nothing.
+func AddedLines(ctx context.Context, dir string, n int) ([]AddedLine, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	if err := run(ctx, dir, "git", "rev-parse", "-q", "--verify", "HEAD"); err != nil {
+		return nil, nil
+	}
+	out, err := output(ctx, dir, "git", "-c", "core.quotePath=false", "log", "-p",
+		"-n", strconv.Itoa(n), "--no-color", "--no-ext-diff", "--unified=0", "--format=%x00%H")
+	if err != nil {
+		return nil, err
+	}
+	return parseLogPatch(out), nil
+}
+
+// parseLogPatch parses `git log -p --unified=0 --format=%x00%H` output into
+// the added lines of each commit.
+func parseLogPatch(out string) []AddedLine {
+	var (
+		lines            []AddedLine
+		commit, path     string
+		lineNo           int
+		oldLeft, newLeft int
+	)
+	for _, line := range strings.Split(out, "\n") {
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if path != "" {
+					lines = append(lines, AddedLine{Commit: commit, Path: path, Line: lineNo, Text: line[1:]})
+				}
+				lineNo++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "\x00"):
+			commit, path = line[1:], ""
+		case strings.HasPrefix(line, "+++ "):
+			// git appends a tab to names containing spaces.
+			path = strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t")
+			if path == "/dev/null" {
+				path = ""
+			} else {
+				path = strings.TrimPrefix(path, "b/")
+			}
+		case strings.HasPrefix(line, "@@ "):
+			lineNo, oldLeft, newLeft = parseHunkHeader(line)
+		}
+	}
+	return lines
+}
+
+// parseHunkHeader reads "@@ -a,b +c,d @@" into the new start line and the
+// old and new line counts (a missing count means 1).
+func parseHunkHeader(line string) (start, oldCount, newCount int) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return 0, 0, 0
+	}
+	_, oldCount = parseRange(strings.TrimPrefix(fields[1], "-"))
+	start, newCount = parseRange(strings.TrimPrefix(fields[2], "+"))
+	return start, oldCount, newCount
+}
+
+func parseRange(s string) (start, count int) {
+	a, b, ok := strings.Cut(s, ",")
+	start, _ = strconv.Atoi(a)
+	count = 1
+	if ok {
+		count, _ = strconv.Atoi(b)
+	}
+	return start, count
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+)
+
+// HooksDir returns the directory git runs hooks from for the repository at
+// dir, honouring core.hooksPath.
+func HooksDir(ctx context.Context, dir string) (string, error) {
+	out, err := output(ctx, dir, "git", "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(dir, out)
+	}
+	return out, nil
+}
+
+// StagedFiles returns the files added, copied, modified or renamed in the
+// index of the repository at dir, i.e. what the next commit would write.
+func StagedFiles(ctx context.Context, dir string) ([]string, error) {
+	out, err := output(ctx, dir, "git", "-c", "core.quotePath=false", "diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// StagedContent returns the staged (index) version of path.
+func StagedContent(ctx context.Context, dir, path string) ([]byte, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+	cmd := command(ctx, dir, "git", "cat-file", "blob", ":"+path)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, wrapErr(ctx, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
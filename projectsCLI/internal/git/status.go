@@ -0,0 +1,121 @@
+package git
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RepoStatus is a detailed snapshot of a repository's state.
+type RepoStatus struct {
+	Branch     string      `json:"branch"`
+	Detached   bool        `json:"detached,omitempty"`
+	Upstream   string      `json:"upstream,omitempty"`
+	Ahead      int         `json:"ahead"`
+	Behind     int         `json:"behind"`
+	Staged     int         `json:"staged"`
+	Unstaged   int         `json:"unstaged"`
+	Untracked  int         `json:"untracked"`
+	Conflicted int         `json:"conflicted"`
+	Stashes    int         `json:"stashes"`
+	LastCommit *CommitInfo `json:"last_commit,omitempty"`
+}
+
+// CommitInfo describes a single commit.
+type CommitInfo struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Time    time.Time `json:"time"`
+	Subject string    `json:"subject"`
+}
+
+// Dirty reports whether the working tree has any changes.
+func (s RepoStatus) Dirty() bool {
+	return s.Staged+s.Unstaged+s.Untracked+s.Conflicted > 0
+}
+
+// Inspect collects branch, upstream, ahead/behind, change counts, stash count
+// and last commit for the repository in dir.
+func Inspect(ctx context.Context, dir string) (*RepoStatus, error) {
+	out, err := output(ctx, dir, "git", "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, err
+	}
+	st := ParsePorcelainV2(out)
+
+	if stashes, err := output(ctx, dir, "git", "stash", "list", "--format=%gd"); err == nil && stashes != "" {
+		st.Stashes = len(strings.Split(stashes, "\n"))
+	}
+
+	// Fails on a repo with no commits yet; LastCommit stays nil.
+	st.LastCommit, _ = LastCommit(ctx, dir)
+
+	return &st, nil
+}
+
+// LastCommit returns the commit HEAD points at.
+func LastCommit(ctx context.Context, dir string) (*CommitInfo, error) {
+	out, err := output(ctx, dir, "git", "log", "-1", "--format=%H%x00%an%x00%ct%x00%s")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitLine(out), nil
+}
+
+func parseCommitLine(line string) *CommitInfo {
+	parts := strings.SplitN(line, "\x00", 4)
+	if len(parts) != 4 {
+		return nil
+	}
+	info := &CommitInfo{Hash: parts[0], Author: parts[1], Subject: parts[3]}
+	if secs, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+		info.Time = time.Unix(secs, 0).UTC()
+	}
+	return info
+}
+
+// ParsePorcelainV2 parses `git status --porcelain=v2 --branch` output.
+func ParsePorcelainV2(out string) RepoStatus {
+	var st RepoStatus
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			head := strings.TrimPrefix(line, "# branch.head ")
+			if head == "(detached)" {
+				st.Detached = true
+			} else {
+				st.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			st.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			var ahead, behind int
+			for _, f := range strings.Fields(strings.TrimPrefix(line, "# branch.ab ")) {
+				n, _ := strconv.Atoi(f[1:])
+				if f[0] == '+' {
+					ahead = n
+				} else {
+					behind = n
+				}
+			}
+			st.Ahead, st.Behind = ahead, behind
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
+			if len(line) < 4 {
+				continue
+			}
+			// XY: X is the index (staged) state, Y the worktree state.
+			if line[2] != '.' {
+				st.Staged++
+			}
+			if line[3] != '.' {
+				st.Unstaged++
+			}
+		case strings.HasPrefix(line, "u "):
+			st.Conflicted++
+		case strings.HasPrefix(line, "? "):
+			st.Untracked++
+		}
+	}
+	return st
+}
@@ -0,0 +1,58 @@
+package git
+
+import "testing"
+
+func TestParsePorcelainV2(t *testing.T) {
+	out := `# branch.oid 1a2b3c
+# branch.head feature/login
+# branch.upstream origin/feature/login
+# branch.ab +12 -3
+1 M. N... 100644 100644 100644 abc def src/a.go
+1 .M N... 100644 100644 100644 abc def src/b.go
+1 MM N... 100644 100644 100644 abc def src/c.go
+2 R. N... 100644 100644 100644 abc def R100 new.go	old.go
+u UU N... 100644 100644 100644 100644 abc def ghi conflict.go
+? notes.txt
+? scratch/`
+
+	st := ParsePorcelainV2(out)
+	want := RepoStatus{
+		Branch:     "feature/login",
+		Upstream:   "origin/feature/login",
+		Ahead:      12,
+		Behind:     3,
+		Staged:     3,
+		Unstaged:   2,
+		Untracked:  2,
+		Conflicted: 1,
+	}
+	if st != want {
+		t.Errorf("ParsePorcelainV2 = %+v, want %+v", st, want)
+	}
+	if !st.Dirty() {
+		t.Error("expected Dirty() to be true")
+	}
+}
+
+func TestParsePorcelainV2Detached(t *testing.T) {
+	st := ParsePorcelainV2("# branch.oid 1a2b3c\n# branch.head (detached)")
+	if !st.Detached || st.Branch != "" || st.Upstream != "" || st.Dirty() {
+		t.Errorf("unexpected detached status: %+v", st)
+	}
+}
+
+func TestParseCommitLine(t *testing.T) {
+	info := parseCommitLine("abc123\x00Ada Lovelace\x001700000000\x00Fix: handle\x00odd subjects")
+	if info == nil {
+		t.Fatal("expected commit info")
+	}
+	if info.Hash != "abc123" || info.Author != "Ada Lovelace" || info.Subject != "Fix: handle\x00odd subjects" {
+		t.Errorf("unexpected commit info: %+v", info)
+	}
+	if info.Time.Unix() != 1700000000 {
+		t.Errorf("Time = %v", info.Time)
+	}
+	if parseCommitLine("garbage") != nil {
+		t.Error("expected nil for malformed line")
+	}
+}
@@ -0,0 +1,171 @@
+// Package hooks writes and inspects the git hooks projects manages in each
+// project. The hook scripts are thin wrappers that call back into
+// `projects hooks run`, so the checks themselves live in the binary and only
+// change when the script format does.
+package hooks
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is the current managed script version. Bump it whenever Script
+// changes so `hooks status` reports installed copies as outdated.
+const Version = 1
+
+// Names are the hooks projects manages.
+var Names = []string{"pre-commit", "commit-msg"}
+
+// marker starts the second line of every managed script, followed by the
+// version, e.g. "# managed by projects hooks v1".
+const marker = "# managed by projects hooks v"
+
+// LocalSuffix is appended to a pre-existing hook when it is replaced; the
+// managed script runs it first.
+const LocalSuffix = ".local"
+
+// State describes an installed hook relative to the current Version.
+type State string
+
+const (
+	Current  State = "current"  // managed, at Version
+	Outdated State = "outdated" // managed, older (or newer) version
+	Missing  State = "missing"  // no hook installed
+	Foreign  State = "foreign"  // a hook projects didn't write
+)
+
+// ErrForeign is returned by Install when a hook projects didn't write is in
+// the way.
+var ErrForeign = errors.New("an existing hook is not managed by projects")
+
+// Script returns the managed script for a hook.
+func Script(name string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s%d — reinstall with 'projects hooks install'; bypass once with --no-verify
+hook_dir=$(dirname "$0")
+if [ -x "$hook_dir/%[3]s%[4]s" ]; then
+	"$hook_dir/%[3]s%[4]s" "$@" || exit $?
+fi
+if command -v projects >/dev/null 2>&1; then
+	exec projects hooks run %[3]s "$@"
+fi
+echo "projects: not on PATH; skipping %[3]s checks" >&2
+exit 0
+`, marker, Version, name, LocalSuffix)
+}
+
+// Inspect reports the state of hook name in hooksDir and, for managed hooks,
+// the installed version.
+func Inspect(hooksDir, name string) (State, int, error) {
+	f, err := os.Open(filepath.Join(hooksDir, name))
+	if os.IsNotExist(err) {
+		return Missing, 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for i := 0; i < 2 && sc.Scan(); i++ {
+		rest, ok := strings.CutPrefix(sc.Text(), marker)
+		if !ok {
+			continue
+		}
+		digits, _, _ := strings.Cut(rest, " ")
+		v, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		if v == Version {
+			return Current, v, nil
+		}
+		return Outdated, v, nil
+	}
+	return Foreign, 0, sc.Err()
+}
+
+// Install writes the managed script for name into hooksDir. A foreign hook
+// is left alone and ErrForeign returned, unless force is set, in which case
+// it is renamed with LocalSuffix so the managed script still runs it. A hook
+// kept that way earlier is never overwritten.
+func Install(hooksDir, name string, force bool) error {
+	state, _, err := Inspect(hooksDir, name)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(hooksDir, name)
+	if state == Foreign {
+		if !force {
+			return ErrForeign
+		}
+		local := path + LocalSuffix
+		if _, err := os.Lstat(local); err == nil {
+			return fmt.Errorf("%s already keeps an earlier hook; merge %s into it or remove it, then reinstall", local, path)
+		}
+		if err := os.Rename(path, local); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return err
+	}
+
+	// Write then rename so a commit running concurrently never sees half a script.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(Script(name)), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// exemptPrefixes are subjects git writes itself; they skip the convention.
+var exemptPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+// CheckMessage validates a commit message's subject line against pattern, a
+// regular expression. Comment lines and anything below git's scissors line
+// are ignored. An empty pattern accepts any non-empty subject.
+func CheckMessage(msg, pattern string) error {
+	subject := Subject(msg)
+	if subject == "" {
+		return errors.New("commit message is empty")
+	}
+	if pattern == "" {
+		return nil
+	}
+	for _, p := range exemptPrefixes {
+		if strings.HasPrefix(subject, p) {
+			return nil
+		}
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid commit_msg_pattern %q: %w", pattern, err)
+	}
+	if !re.MatchString(subject) {
+		return fmt.Errorf("commit subject %q doesn't match the required pattern %s", subject, pattern)
+	}
+	return nil
+}
+
+// Subject returns the first non-comment, non-blank line of a commit message.
+func Subject(msg string) string {
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(line, "# ------------------------ >8 ------------------------") {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if s := strings.TrimSpace(line); s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallAndInspect(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	if state, _, err := Inspect(dir, "pre-commit"); err != nil || state != Missing {
+		t.Fatalf("Inspect before install = %s, %v", state, err)
+	}
+	if err := Install(dir, "pre-commit", false); err != nil {
+		t.Fatal(err)
+	}
+	state, version, err := Inspect(dir, "pre-commit")
+	if err != nil || state != Current || version != Version {
+		t.Fatalf("Inspect after install = %s v%d, %v", state, version, err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "pre-commit"))
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("hook not executable: %v, %v", info.Mode(), err)
+	}
+
+	old := strings.Replace(Script("pre-commit"), marker+"1", marker+"0", 1)
+	if err := os.WriteFile(filepath.Join(dir, "pre-commit"), []byte(old), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if state, version, _ := Inspect(dir, "pre-commit"); state != Outdated || version != 0 {
+		t.Errorf("Inspect old script = %s v%d, want outdated v0", state, version)
+	}
+}
+
+func TestInstallForeign(t *testing.T) {
+	dir := t.TempDir()
+	custom := "#!/bin/sh\nnpx lint-staged\n"
+	if err := os.WriteFile(filepath.Join(dir, "pre-commit"), []byte(custom), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if state, _, _ := Inspect(dir, "pre-commit"); state != Foreign {
+		t.Fatalf("state = %s, want foreign", state)
+	}
+	if err := Install(dir, "pre-commit", false); !errors.Is(err, ErrForeign) {
+		t.Fatalf("Install without force = %v, want ErrForeign", err)
+	}
+	if err := Install(dir, "pre-commit", true); err != nil {
+		t.Fatal(err)
+	}
+	kept, err := os.ReadFile(filepath.Join(dir, "pre-commit"+LocalSuffix))
+	if err != nil || string(kept) != custom {
+		t.Errorf("foreign hook not kept: %q, %v", kept, err)
+	}
+	if !strings.Contains(Script("pre-commit"), "pre-commit"+LocalSuffix) {
+		t.Error("managed script doesn't chain the local hook")
+	}
+
+	// Another tool rewrites the hook; forcing again keeps the first one.
+	if err := os.WriteFile(filepath.Join(dir, "pre-commit"), []byte("#!/bin/sh\nhusky\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(dir, "pre-commit", true); err == nil {
+		t.Error("Install overwrote the kept local hook")
+	}
+	if kept, err := os.ReadFile(filepath.Join(dir, "pre-commit"+LocalSuffix)); err != nil || string(kept) != custom {
+		t.Errorf("local hook = %q, %v", kept, err)
+	}
+}
+
+func TestCheckMessage(t *testing.T) {
+	const pattern = `^(feat|fix|docs)(\(.+\))?: `
+	tests := []struct {
+		msg     string
+		pattern string
+		ok      bool
+	}{
+		{"feat: add login\n", pattern, true},
+		{"# Please enter the commit message\nfix(api): handle 404\n\nbody", pattern, true},
+		{"Add login\n", pattern, false},
+		{"Merge branch 'main' into feature\n", pattern, true},
+		{"fixup! feat: add login\n", pattern, true},
+		{"anything goes\n", "", true},
+		{"# only comments\n\n", "", false},
+		{"feat: x\n# ------------------------ >8 ------------------------\ndiff", pattern, true},
+	}
+	for _, tt := range tests {
+		err := CheckMessage(tt.msg, tt.pattern)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckMessage(%q, %q) = %v, want ok=%v", tt.msg, tt.pattern, err, tt.ok)
+		}
+	}
+	if err := CheckMessage("feat: x", "("); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("bad pattern error = %v", err)
+	}
+}
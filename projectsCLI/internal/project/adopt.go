@@ -0,0 +1,227 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Infer builds metadata for an existing directory that has no PROJECT.md:
+// the title and description come from its README and the tags from the
+// languages its files are written in.
+func Infer(dir, slug string) ProjectMeta {
+	title, description := readmeSummary(dir)
+	meta := NewMeta(slug, title)
+	meta.Description = description
+	meta.Tags = Languages(dir)
+	return meta
+}
+
+// readmeSummary returns the first heading of dir's README and the first
+// line of prose after it.
+func readmeSummary(dir string) (title, description string) {
+	for _, name := range []string{"README.md", "readme.md", "README.markdown", "README"} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			switch {
+			case line == "":
+			case strings.HasPrefix(line, "#"):
+				if title == "" {
+					title = strings.TrimSpace(strings.TrimLeft(line, "#"))
+				}
+			// Skip badges, images, HTML and tables.
+			case strings.HasPrefix(line, "[!["), strings.HasPrefix(line, "!["),
+				strings.HasPrefix(line, "<"), strings.HasPrefix(line, "|"):
+			default:
+				if title != "" && description == "" {
+					return title, line
+				}
+			}
+		}
+		return title, description
+	}
+	return "", ""
+}
+
+// languageByExt maps file extensions to the tag used for the language.
+var languageByExt = map[string]string{
+	".go":    "go",
+	".rs":    "rust",
+	".py":    "python",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".mjs":   "javascript",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".rb":    "ruby",
+	".java":  "java",
+	".kt":    "kotlin",
+	".swift": "swift",
+	".c":     "c",
+	".h":     "c",
+	".cc":    "cpp",
+	".cpp":   "cpp",
+	".hpp":   "cpp",
+	".cs":    "csharp",
+	".php":   "php",
+	".ex":    "elixir",
+	".exs":   "elixir",
+	".hs":    "haskell",
+	".lua":   "lua",
+	".dart":  "dart",
+	".scala": "scala",
+	".zig":   "zig",
+	".sh":    "shell",
+}
+
+// skipDirs are never looked into when counting languages.
+var skipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "target": true, "dist": true, "build": true,
+	"__pycache__": true, "venv": true, "private": true,
+}
+
+// maxLanguageFiles bounds the walk in Languages.
+const maxLanguageFiles = 10000
+
+// maxLanguages is how many language tags Languages returns at most.
+const maxLanguages = 3
+
+var errEnoughFiles = errors.New("enough files")
+
+// Languages returns the main languages of the source files under dir, most
+// files first. Hidden and dependency directories are skipped.
+func Languages(dir string) []string {
+	counts := map[string]int{}
+	seen := 0
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && (strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if lang, ok := languageByExt[strings.ToLower(filepath.Ext(d.Name()))]; ok {
+			counts[lang]++
+		}
+		if seen++; seen >= maxLanguageFiles {
+			return errEnoughFiles
+		}
+		return nil
+	})
+
+	langs := make([]string, 0, len(counts))
+	for lang := range counts {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if counts[langs[i]] != counts[langs[j]] {
+			return counts[langs[i]] > counts[langs[j]]
+		}
+		return langs[i] < langs[j]
+	})
+	if len(langs) > maxLanguages {
+		langs = langs[:maxLanguages]
+	}
+	return langs
+}
+
+// CompleteScaffold adds whatever parts of the project scaffold dir is
+// missing, leaving existing files alone. An existing .gitignore that doesn't
+// ignore private/ and private.vault gets the missing rules appended. It
+// returns the slash-separated paths it created or changed.
+func CompleteScaffold(dir string, meta ProjectMeta) ([]string, error) {
+	var changed []string
+	for _, d := range scaffoldDirs {
+		path := filepath.Join(dir, d)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return changed, fmt.Errorf("create directory %s: %w", path, err)
+		}
+		changed = append(changed, d+"/")
+	}
+
+	names := make([]string, 0, len(scaffoldFiles))
+	for name := range scaffoldFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(scaffoldFiles[name](meta)), 0644); err != nil {
+			return changed, fmt.Errorf("write %s: %w", name, err)
+		}
+		changed = append(changed, name)
+	}
+
+	missing, err := missingIgnoreRules(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return changed, err
+	}
+	if len(missing) > 0 {
+		add := gitignoreTemplate
+		if len(missing) < len(privateIgnoreRules) {
+			add = strings.Join(missing, "\n") + "\n"
+		}
+		f, err := os.OpenFile(filepath.Join(dir, ".gitignore"), os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return changed, err
+		}
+		defer f.Close()
+		if _, err := f.WriteString("\n" + add); err != nil {
+			return changed, fmt.Errorf("update .gitignore: %w", err)
+		}
+		changed = append(changed, ".gitignore")
+	}
+	return changed, nil
+}
+
+// privateIgnoreRules are the .gitignore rules that keep private/ and its
+// vault out of git, each with the spellings that count as the same rule.
+var privateIgnoreRules = []struct {
+	rule  string
+	forms []string
+}{
+	{"private/", []string{"private", "private/", "/private", "/private/"}},
+	{"private.vault", []string{"private.vault", "/private.vault"}},
+}
+
+// missingIgnoreRules returns the private rules the .gitignore at path
+// doesn't have.
+func missingIgnoreRules(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	var missing []string
+	for _, r := range privateIgnoreRules {
+		if !slices.ContainsFunc(r.forms, func(form string) bool { return slices.Contains(lines, form) }) {
+			missing = append(missing, r.rule)
+		}
+	}
+	return missing, nil
+}
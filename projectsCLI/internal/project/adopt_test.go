@@ -0,0 +1,88 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestInfer(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":               "[![CI](badge.svg)](ci)\n\n# Tiny Tool\n\n![logo](logo.png)\nDoes one small thing well.\n",
+		"main.go":                 "package main",
+		"cmd/run.go":              "package cmd",
+		"web/app.ts":              "",
+		"scripts/build.sh":        "",
+		"node_modules/x/index.js": "",
+		".github/workflows/a.py":  "",
+		"vendor/lib/vendored.rs":  "",
+		"private/notes.txt":       "",
+	})
+
+	meta := Infer(dir, "tiny")
+	if meta.Slug != "tiny" || meta.Title != "Tiny Tool" || meta.Description != "Does one small thing well." {
+		t.Errorf("meta = %+v", meta)
+	}
+	if got := strings.Join(meta.Tags, ","); got != "go,shell,typescript" {
+		t.Errorf("tags = %s", got)
+	}
+
+	if meta := Infer(t.TempDir(), "bare"); meta.Title != "bare" || len(meta.Tags) != 0 {
+		t.Errorf("empty dir meta = %+v", meta)
+	}
+}
+
+func TestCompleteScaffold(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"docs/README.md": "my own docs\n",
+		".gitignore":     "node_modules/",
+	})
+
+	added, err := CompleteScaffold(dir, NewMeta("demo", "Demo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "memory/ context/ tasks/ code/ private/ USAGE.md context/CONTEXT.md memory/MEMORY.md tasks/TODO.md .gitignore"
+	if got := strings.Join(added, " "); got != want {
+		t.Errorf("added = %q, want %q", got, want)
+	}
+	docs, _ := os.ReadFile(filepath.Join(dir, "docs", "README.md"))
+	ignore, _ := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if string(docs) != "my own docs\n" || !strings.HasPrefix(string(ignore), "node_modules/\n") || !strings.Contains(string(ignore), "\nprivate/\n") {
+		t.Errorf("docs = %q, .gitignore = %q", docs, ignore)
+	}
+
+	if again, err := CompleteScaffold(dir, NewMeta("demo", "Demo")); err != nil || len(again) != 0 {
+		t.Errorf("second run added %q, %v", again, err)
+	}
+
+	// An adopted PROJECT.md may have no created_at.
+	if _, err := CompleteScaffold(t.TempDir(), ProjectMeta{Slug: "bare", Title: "Bare"}); err != nil {
+		t.Errorf("scaffold without created_at: %v", err)
+	}
+
+	// A .gitignore that already ignores private/ only gets the vault rule.
+	writeFiles(t, dir, map[string]string{".gitignore": "/private\n"})
+	if _, err := CompleteScaffold(dir, NewMeta("demo", "Demo")); err != nil {
+		t.Fatal(err)
+	}
+	if ignore, _ := os.ReadFile(filepath.Join(dir, ".gitignore")); string(ignore) != "/private\n\nprivate.vault\n" {
+		t.Errorf(".gitignore = %q, want the vault rule appended", ignore)
+	}
+}
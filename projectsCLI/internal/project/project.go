@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,9 +19,18 @@ type ProjectMeta struct {
 	Status      string   `yaml:"status" json:"status"`
 	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
 	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Homepage    string   `yaml:"homepage,omitempty" json:"homepage,omitempty"`
 	CreatedAt   string   `yaml:"created_at" json:"created_at"`
 	UpdatedAt   string   `yaml:"updated_at" json:"updated_at"`
 	GitRemote   string   `yaml:"git_remote,omitempty" json:"git_remote,omitempty"`
+
+	// Scripts maps a name to a shell command run from the project root,
+	// e.g. dev: "cd code && npm run dev".
+	Scripts map[string]string `yaml:"scripts,omitempty" json:"scripts,omitempty"`
+
+	// Extra holds any frontmatter keys projects doesn't know about, so they
+	// survive a rewrite of PROJECT.md.
+	Extra map[string]any `yaml:",inline" json:"extra,omitempty"`
 }
 
 // Project is a fully loaded project with its metadata, body, and filesystem path.
@@ -29,6 +39,21 @@ type Project struct {
 	Body   string      `json:"body,omitempty"`
 	Dir    string      `json:"dir"`
 	Folder string      `json:"folder,omitempty"`
+	// External is set for projects whose files live outside the projects
+	// directory: symlinked in, or listed under links in the config.
+	External bool `json:"external,omitempty"`
+	// Workspace is set when projects are listed across workspaces.
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// ScriptNames returns the project's script names in sorted order.
+func (m ProjectMeta) ScriptNames() []string {
+	names := make([]string, 0, len(m.Scripts))
+	for name := range m.Scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // ProjectFilePath returns the PROJECT.md path for a project directory.
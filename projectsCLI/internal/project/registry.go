@@ -8,7 +8,8 @@ import (
 	"strings"
 )
 
-// ListProjects scans the projects directory and returns all valid projects.
+// ListProjects scans the projects directory and returns all valid projects,
+// including ones symlinked into it, which are marked external.
 func ListProjects(projectsDir string) ([]*Project, error) {
 	entries, err := os.ReadDir(projectsDir)
 	if os.IsNotExist(err) {
@@ -20,11 +21,20 @@ func ListProjects(projectsDir string) ([]*Project, error) {
 
 	var projects []*Project
 	for _, entry := range entries {
-		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+		if strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
-
 		projDir := filepath.Join(projectsDir, entry.Name())
+		linked := !entry.IsDir()
+		if linked {
+			// Follow symlinks to projects that live elsewhere.
+			if entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+			if info, err := os.Stat(projDir); err != nil || !info.IsDir() {
+				continue
+			}
+		}
 		projFile := ProjectFilePath(projDir)
 
 		if _, err := os.Stat(projFile); err != nil {
@@ -35,6 +45,7 @@ func ListProjects(projectsDir string) ([]*Project, error) {
 		if err != nil {
 			continue // skip unparseable projects
 		}
+		proj.External = linked
 
 		projects = append(projects, proj)
 	}
@@ -46,21 +57,66 @@ func ListProjects(projectsDir string) ([]*Project, error) {
 	return projects, nil
 }
 
-// FindProject finds a project by slug in the projects directory.
+// ListLinked returns the projects at the given external roots, marked
+// external. A root with a PROJECT.md is a project itself; any other root is
+// scanned for projects like the projects directory. Missing roots are skipped.
+func ListLinked(roots []string) []*Project {
+	var projects []*Project
+	for _, root := range roots {
+		if proj, err := LoadProject(root); err == nil {
+			proj.External = true
+			projects = append(projects, proj)
+			continue
+		}
+		found, err := ListProjects(root)
+		if err != nil {
+			continue
+		}
+		for _, proj := range found {
+			proj.External = true
+			projects = append(projects, proj)
+		}
+	}
+	return projects
+}
+
+// RealDir resolves the symlinks in dir, returning dir unchanged if that
+// fails.
+func RealDir(dir string) string {
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		return real
+	}
+	return dir
+}
+
+// FindProject finds a project by slug in the projects directory. A project
+// symlinked in is marked external, as ListProjects does.
 func FindProject(projectsDir, slug string) (*Project, error) {
 	dir := filepath.Join(projectsDir, slug)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return nil, fmt.Errorf("project %q not found", slug)
 	}
-	return LoadProject(dir)
+	proj, err := LoadProject(dir)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		proj.External = true
+	}
+	return proj, nil
 }
 
-// WriteRegistry regenerates PROJECTS.md from the filesystem.
-func WriteRegistry(projectsDir string) error {
+// WriteRegistry regenerates PROJECTS.md from the filesystem. Projects under
+// the external roots in links are listed too.
+func WriteRegistry(projectsDir string, links ...string) error {
 	projects, err := ListProjects(projectsDir)
 	if err != nil {
 		return err
 	}
+	projects = append(projects, ListLinked(links)...)
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].Meta.Slug < projects[j].Meta.Slug
+	})
 
 	var sb strings.Builder
 	sb.WriteString("# Projects\n\n")
@@ -76,8 +132,12 @@ func WriteRegistry(projectsDir string) error {
 			if len(created) > 10 {
 				created = created[:10]
 			}
+			slug := p.Meta.Slug
+			if p.External {
+				slug += " (external: " + RealDir(p.Dir) + ")"
+			}
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
-				p.Meta.Slug, p.Meta.Title, p.Meta.Status, created))
+				slug, p.Meta.Title, p.Meta.Status, created))
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Scaffold creates the full directory tree and template files for a new project.
@@ -15,18 +16,9 @@ func Scaffold(projectsDir string, meta ProjectMeta) (string, error) {
 	}
 
 	// Create directory tree.
-	dirs := []string{
-		dir,
-		filepath.Join(dir, "docs"),
-		filepath.Join(dir, "memory"),
-		filepath.Join(dir, "context"),
-		filepath.Join(dir, "tasks"),
-		filepath.Join(dir, "code"),
-		filepath.Join(dir, "private"),
-	}
-	for _, d := range dirs {
-		if err := os.MkdirAll(d, 0755); err != nil {
-			return "", fmt.Errorf("create directory %s: %w", d, err)
+	for _, d := range append([]string{""}, scaffoldDirs...) {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			return "", fmt.Errorf("create directory %s: %w", filepath.Join(dir, d), err)
 		}
 	}
 
@@ -37,17 +29,9 @@ func Scaffold(projectsDir string, meta ProjectMeta) (string, error) {
 	}
 
 	// Write template files.
-	templates := map[string]string{
-		filepath.Join(dir, "USAGE.md"):               usageTemplate(meta),
-		filepath.Join(dir, "memory", "MEMORY.md"):    memoryTemplate(meta),
-		filepath.Join(dir, "context", "CONTEXT.md"):  contextTemplate(meta),
-		filepath.Join(dir, "tasks", "TODO.md"):       tasksTemplate(meta),
-		filepath.Join(dir, "docs", "README.md"):      fmt.Sprintf("# %s\n\n%s\n", meta.Title, meta.Description),
-		filepath.Join(dir, ".gitignore"):              "# Private files — never pushed to remote\nprivate/\n",
-	}
-
-	for path, content := range templates {
-		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	for name, render := range scaffoldFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.WriteFile(path, []byte(render(meta)), 0644); err != nil {
 			return "", fmt.Errorf("write %s: %w", filepath.Base(path), err)
 		}
 	}
@@ -55,6 +39,22 @@ func Scaffold(projectsDir string, meta ProjectMeta) (string, error) {
 	return dir, nil
 }
 
+// scaffoldDirs are the directories every project has.
+var scaffoldDirs = []string{"docs", "memory", "context", "tasks", "code", "private"}
+
+// scaffoldFiles are the template files of a new project, keyed by
+// slash-separated path relative to the project root.
+var scaffoldFiles = map[string]func(ProjectMeta) string{
+	"USAGE.md":           usageTemplate,
+	"memory/MEMORY.md":   memoryTemplate,
+	"context/CONTEXT.md": contextTemplate,
+	"tasks/TODO.md":      tasksTemplate,
+	"docs/README.md":     readmeTemplate,
+	".gitignore":         func(ProjectMeta) string { return gitignoreTemplate },
+}
+
+const gitignoreTemplate = "# Private files — never pushed to remote\nprivate/\nprivate.vault\n"
+
 func usageTemplate(meta ProjectMeta) string {
 	return fmt.Sprintf(`# %s — Project Guide
 
@@ -116,7 +116,23 @@ Persistent notes and context for this project. Add anything here that should sur
 ## Notes
 
 _Nothing yet. Add notes as the project evolves._
-`, meta.Title, meta.CreatedAt[:10], meta.Status)
+`, meta.Title, createdDate(meta), meta.Status)
+}
+
+// createdDate is the day part of meta's created_at, or today for an
+// adopted PROJECT.md that doesn't have one.
+func createdDate(meta ProjectMeta) string {
+	if len(meta.CreatedAt) >= 10 {
+		return meta.CreatedAt[:10]
+	}
+	if meta.CreatedAt != "" {
+		return meta.CreatedAt
+	}
+	return time.Now().UTC().Format(time.DateOnly)
+}
+
+func readmeTemplate(meta ProjectMeta) string {
+	return fmt.Sprintf("# %s\n\n%s\n", meta.Title, meta.Description)
 }
 
 func contextTemplate(meta ProjectMeta) string {
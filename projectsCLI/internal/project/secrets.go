@@ -0,0 +1,154 @@
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Secret is a single KEY=value pair loaded from a project's private/.env.
+type Secret struct {
+	Key   string
+	Value string
+}
+
+// SecretsFilePath returns the private/.env path for a project directory.
+func SecretsFilePath(dir string) string {
+	return filepath.Join(dir, "private", ".env")
+}
+
+// LoadSecrets reads private/.env for a project. A missing file yields no
+// secrets and no error.
+func LoadSecrets(projectDir string) ([]Secret, error) {
+	f, err := os.Open(SecretsFilePath(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read secrets: %w", err)
+	}
+	defer f.Close()
+
+	secrets, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", SecretsFilePath(projectDir), err)
+	}
+	return secrets, nil
+}
+
+var dotenvKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// ParseDotenv parses dotenv syntax: KEY=value lines with optional "export "
+// prefixes, # comments, single quotes (literal), double quotes (with \n, \",
+// \\ and \$ escapes, may span lines) and unquoted values with trailing
+// " # comments". Later duplicates override earlier ones.
+func ParseDotenv(r io.Reader) ([]Secret, error) {
+	scanner := bufio.NewScanner(r)
+	var secrets []Secret
+	index := map[string]int{}
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if !dotenvKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key %q", lineNo, key)
+		}
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value string
+		switch {
+		case strings.HasPrefix(rest, "'"):
+			end := strings.Index(rest[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single quote", lineNo)
+			}
+			value = rest[1 : end+1]
+
+		case strings.HasPrefix(rest, `"`):
+			raw := rest[1:]
+			for {
+				if end := closingQuote(raw); end >= 0 {
+					raw = raw[:end]
+					break
+				}
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated double quote", lineNo)
+				}
+				lineNo++
+				raw += "\n" + scanner.Text()
+			}
+			value = unescapeDotenv(raw)
+
+		default:
+			value = rest
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		if i, seen := index[key]; seen {
+			secrets[i].Value = value
+			continue
+		}
+		index[key] = len(secrets)
+		secrets = append(secrets, Secret{Key: key, Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// closingQuote returns the index of the first unescaped double quote in s, or -1.
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '"', '\\', '$':
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	input := `# database
+DATABASE_URL=postgres://localhost/app
+export API_KEY = abc123 # inline comment
+SINGLE='literal $HOME \n'
+DOUBLE="tab\there \"quoted\" \$HOME"
+MULTI="line one
+line two"
+HASH=value#not-a-comment
+EMPTY=
+
+API_KEY=override
+`
+	secrets, err := ParseDotenv(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDotenv: %v", err)
+	}
+
+	want := []Secret{
+		{"DATABASE_URL", "postgres://localhost/app"},
+		{"API_KEY", "override"},
+		{"SINGLE", `literal $HOME \n`},
+		{"DOUBLE", "tab\there \"quoted\" $HOME"},
+		{"MULTI", "line one\nline two"},
+		{"HASH", "value#not-a-comment"},
+		{"EMPTY", ""},
+	}
+	if len(secrets) != len(want) {
+		t.Fatalf("got %d secrets %v, want %d", len(secrets), secrets, len(want))
+	}
+	for i := range want {
+		if secrets[i] != want[i] {
+			t.Errorf("secret %d = %+v, want %+v", i, secrets[i], want[i])
+		}
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	for _, input := range []string{
+		"NOEQUALS",
+		"1BAD=x",
+		"OPEN='never closed",
+		`OPEN="never closed`,
+	} {
+		if _, err := ParseDotenv(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseDotenv(%q) expected error, got nil", input)
+		}
+	}
+}
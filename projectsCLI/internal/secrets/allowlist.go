@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AllowlistFile is the per-project allowlist, read from the project root.
+const AllowlistFile = ".scanignore"
+
+// Allowlist suppresses known false positives. Each non-comment line is one of:
+//
+//	<fingerprint>         a finding fingerprint, as printed by scan
+//	<glob>                every finding in matching files
+//	<glob> <rule-id>      one rule in matching files
+//
+// Globs match the slash-separated path from the project root; a glob
+// without a slash matches the file name anywhere, and a trailing slash
+// matches everything under a directory.
+type Allowlist struct {
+	fingerprints map[string]bool
+	paths        []allowPath
+}
+
+type allowPath struct {
+	glob string
+	rule string
+}
+
+var fingerprintRe = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// LoadAllowlist reads the allowlist from a project directory. A missing file
+// yields an empty allowlist.
+func LoadAllowlist(dir string) (*Allowlist, error) {
+	a := &Allowlist{fingerprints: map[string]bool{}}
+	f, err := os.Open(filepath.Join(dir, AllowlistFile))
+	if os.IsNotExist(err) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		a.Add(sc.Text())
+	}
+	return a, sc.Err()
+}
+
+// Add parses one allowlist line.
+func (a *Allowlist) Add(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	if fingerprintRe.MatchString(line) {
+		a.fingerprints[line] = true
+		return
+	}
+	glob, rule, _ := strings.Cut(line, " ")
+	a.paths = append(a.paths, allowPath{glob: glob, rule: strings.TrimSpace(rule)})
+}
+
+// Allows reports whether f is allowlisted.
+func (a *Allowlist) Allows(f Finding) bool {
+	if a == nil {
+		return false
+	}
+	if a.fingerprints[f.Fingerprint] {
+		return true
+	}
+	for _, p := range a.paths {
+		if (p.rule == "" || p.rule == f.Rule) && matchPath(p.glob, f.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the findings that are not allowlisted.
+func (a *Allowlist) Filter(findings []Finding) []Finding {
+	var kept []Finding
+	for _, f := range findings {
+		if !a.Allows(f) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+func matchPath(glob, p string) bool {
+	if strings.HasSuffix(glob, "/") {
+		return strings.HasPrefix(p, glob) || strings.Contains(p, "/"+glob)
+	}
+	if !strings.Contains(glob, "/") {
+		ok, _ := path.Match(glob, path.Base(p))
+		return ok
+	}
+	ok, _ := path.Match(strings.TrimPrefix(glob, "/"), p)
+	return ok
+}
@@ -0,0 +1,146 @@
+package secrets
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+)
+
+// Report is the set of findings for one scanned project.
+type Report struct {
+	Name     string    `json:"slug"`
+	Root     string    `json:"dir"`
+	Findings []Finding `json:"findings"`
+}
+
+// SARIF 2.1.0, trimmed to the fields scanners and code-scanning UIs use.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLoc `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult               `json:"results"`
+	Properties         map[string]string           `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysical `json:"physicalLocation"`
+}
+
+type sarifPhysical struct {
+	ArtifactLocation sarifArtifactLoc `json:"artifactLocation"`
+	Region           *sarifRegion     `json:"region,omitempty"`
+}
+
+type sarifArtifactLoc struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSARIF writes reports as a SARIF 2.1.0 log with one run per project.
+// Locations are relative to a PROJECTROOT base pointing at the project dir.
+func WriteSARIF(w io.Writer, reports []Report) error {
+	rules := []sarifRule{
+		{ID: HighEntropyRule, ShortDescription: sarifMessage{Text: "High-entropy value in env file"}},
+		{ID: SensitiveFileRule, ShortDescription: sarifMessage{Text: "File that typically holds secrets"}},
+	}
+	for _, r := range Rules {
+		rules = append(rules, sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+	}
+	for _, rep := range reports {
+		run := sarifRun{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "projects scan",
+				InformationURI: "https://github.com/jackmorganxyz/projectsCLI",
+				Rules:          rules,
+			}},
+			OriginalURIBaseIDs: map[string]sarifArtifactLoc{
+				"PROJECTROOT": {URI: fileURI(rep.Root)},
+			},
+			Results:    []sarifResult{},
+			Properties: map[string]string{"project": rep.Name},
+		}
+		for _, f := range rep.Findings {
+			res := sarifResult{
+				RuleID:  f.Rule,
+				Level:   "error",
+				Message: sarifMessage{Text: f.Description},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysical{
+					ArtifactLocation: sarifArtifactLoc{URI: (&url.URL{Path: f.Path}).String(), URIBaseID: "PROJECTROOT"},
+				}}},
+				PartialFingerprints: map[string]string{"secretFingerprint/v1": f.Fingerprint},
+			}
+			if f.Match != "" {
+				res.Message.Text += " (" + f.Match + ")"
+			}
+			if f.Line > 0 {
+				res.Locations[0].PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+			}
+			if f.Commit != "" {
+				res.Message.Text += " in commit " + f.Commit
+				res.Properties = map[string]string{"commit": f.Commit}
+			}
+			run.Results = append(run.Results, res)
+		}
+		log.Runs = append(log.Runs, run)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// fileURI returns a file:// URI for a directory, with a trailing slash as
+// SARIF requires for base URIs.
+func fileURI(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs) + "/"}
+	return u.String()
+}
@@ -0,0 +1,247 @@
+// Package secrets detects credentials that shouldn't be committed: known
+// token formats, private keys, and files whose names suggest secrets.
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Rule is a pattern that identifies one kind of secret.
+type Rule struct {
+	ID          string
+	Description string
+	Pattern     *regexp.Regexp
+}
+
+// Rules are the content patterns checked by Scan, ScanFile and ScanLine.
+var Rules = []Rule{
+	{"private-key", "Private key", regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY( BLOCK)?-----`)},
+	{"aws-access-key", "AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"github-token", "GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`)},
+	{"gitlab-token", "GitLab personal access token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"slack-token", "Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"stripe-key", "Stripe secret key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{20,}\b`)},
+	{"google-api-key", "Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"openai-key", "OpenAI API key", regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{32,}\b`)},
+	{"anthropic-key", "Anthropic API key", regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{32,}\b`)},
+	{"generic-assignment", "Hard-coded credential", regexp.MustCompile(`(?i)\b[a-z0-9_]*(?:password|passwd|secret|api_?key|access_?token|auth_?token|client_?secret)["']?\s*[:=]\s*["']([^"'\s$]{12,})["']`)},
+}
+
+// Rule IDs for checks that aren't content patterns.
+const (
+	// HighEntropyRule flags random-looking values in .env-like files.
+	HighEntropyRule = "high-entropy"
+	// SensitiveFileRule flags files whose name implies secrets.
+	SensitiveFileRule = "sensitive-file"
+)
+
+// Finding is a single suspected secret.
+type Finding struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+	Path        string `json:"path,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Commit      string `json:"commit,omitempty"` // set for findings in history
+	Match       string `json:"match,omitempty"`  // redacted
+	// Fingerprint identifies the finding across runs (rule, path and the
+	// unredacted match) so it can be allowlisted.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// maxLine bounds the line length Scan reads; longer lines are minified
+// assets, not config.
+const maxLine = 1 << 20
+
+// Scan checks data line by line against Rules. Binary data (anything with a
+// NUL byte) is skipped.
+func Scan(data []byte) []Finding {
+	return ScanFile("", data)
+}
+
+// ScanFile is Scan for a named file: findings carry the path, and .env-like
+// files also get the high-entropy value check.
+func ScanFile(p string, data []byte) []Finding {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return nil
+	}
+
+	var findings []Finding
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), maxLine)
+	for n := 1; sc.Scan(); n++ {
+		findings = append(findings, ScanLine(p, n, sc.Text())...)
+	}
+	return findings
+}
+
+// ScanLine checks a single line of file p, e.g. one added in a commit.
+func ScanLine(p string, n int, line string) []Finding {
+	var findings []Finding
+	add := func(rule, desc, match string) {
+		findings = append(findings, Finding{
+			Rule:        rule,
+			Description: desc,
+			Path:        p,
+			Line:        n,
+			Match:       Redact(match),
+			Fingerprint: fingerprint(rule, p, match),
+		})
+	}
+
+	for _, r := range Rules {
+		if m := r.Pattern.FindString(line); m != "" {
+			add(r.ID, r.Description, m)
+		}
+	}
+	if len(findings) == 0 && EnvLike(p) {
+		if v := envValue(line); highEntropy(v) {
+			add(HighEntropyRule, "High-entropy value in env file", v)
+		}
+	}
+	return findings
+}
+
+func fingerprint(rule, p, match string) string {
+	sum := sha256.Sum256([]byte(rule + "\x00" + p + "\x00" + match))
+	return hex.EncodeToString(sum[:8])
+}
+
+// EnvLike reports whether a path looks like a KEY=VALUE config file
+// (.env, .env.local, app.env, *.properties, *.ini, ...). Templates such as
+// .env.example are included: real values get pasted into them too.
+func EnvLike(p string) bool {
+	base := strings.ToLower(path.Base(p))
+	if base == ".env" || strings.HasPrefix(base, ".env.") || strings.HasSuffix(base, ".env") {
+		return true
+	}
+	switch path.Ext(base) {
+	case ".properties", ".ini", ".cfg", ".conf":
+		return true
+	}
+	return false
+}
+
+// envValue extracts the value from a KEY=VALUE or KEY: VALUE line.
+func envValue(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+		return ""
+	}
+	i := strings.IndexAny(line, "=:")
+	if i < 0 {
+		return ""
+	}
+	v := strings.TrimSpace(line[i+1:])
+	if j := strings.Index(v, " #"); j >= 0 {
+		v = strings.TrimSpace(v[:j])
+	}
+	return strings.Trim(v, `"'`)
+}
+
+// highEntropy reports whether v looks like a random token rather than a
+// word, path, URL, version or variable reference.
+func highEntropy(v string) bool {
+	if len(v) < 20 || strings.ContainsAny(v, " $") || strings.Contains(v, "://") {
+		return false
+	}
+	if strings.HasPrefix(v, "/") || strings.HasPrefix(v, "./") || strings.HasPrefix(v, "~") {
+		return false
+	}
+	// Tokens mix letters and digits; hostnames and identifiers rarely do.
+	if !strings.ContainsAny(v, "0123456789") || strings.Trim(v, "0123456789.-_") == "" {
+		return false
+	}
+	return Entropy(v) >= 3.5
+}
+
+// Entropy returns the Shannon entropy of s in bits per character.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// sensitiveNames are file names that hold secrets whatever their contents.
+var sensitiveNames = map[string]string{
+	".env":             "dotenv file",
+	".netrc":           "netrc credentials",
+	".npmrc":           "npm credentials",
+	".pypirc":          "PyPI credentials",
+	"id_rsa":           "SSH private key",
+	"id_dsa":           "SSH private key",
+	"id_ecdsa":         "SSH private key",
+	"id_ed25519":       "SSH private key",
+	"credentials.json": "credentials file",
+	".htpasswd":        "htpasswd file",
+}
+
+var sensitiveExts = map[string]string{
+	".pem":      "PEM key or certificate",
+	".key":      "private key",
+	".p12":      "PKCS#12 keystore",
+	".pfx":      "PKCS#12 keystore",
+	".jks":      "Java keystore",
+	".keystore": "keystore",
+}
+
+// SensitiveName reports whether a slash-separated path names a file that
+// typically holds secrets (.env, id_rsa, *.pem, ...), and why. Templates
+// such as .env.example are not flagged.
+func SensitiveName(p string) (string, bool) {
+	base := path.Base(p)
+	if why, ok := sensitiveNames[base]; ok {
+		return why, true
+	}
+	if strings.HasPrefix(base, ".env.") {
+		switch strings.TrimPrefix(base, ".env.") {
+		case "example", "sample", "template", "dist", "defaults":
+			return "", false
+		}
+		return "dotenv file", true
+	}
+	if why, ok := sensitiveExts[strings.ToLower(path.Ext(base))]; ok {
+		return why, true
+	}
+	return "", false
+}
+
+// SensitiveFile returns a finding for p if its name implies it holds
+// secrets (see SensitiveName).
+func SensitiveFile(p string) (Finding, bool) {
+	why, ok := SensitiveName(p)
+	if !ok {
+		return Finding{}, false
+	}
+	return Finding{
+		Rule:        SensitiveFileRule,
+		Description: "Sensitive file (" + why + ")",
+		Path:        p,
+		Fingerprint: fingerprint(SensitiveFileRule, p, ""),
+	}, true
+}
+
+// Redact keeps enough of a match to recognise it without leaking it.
+func Redact(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", min(len(s)-4, 12))
+}
@@ -0,0 +1,139 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// StateFile is where sync state lives, relative to the project root. It is
+// committed with the project so collaborators share it.
+const StateFile = "tasks/.issues.json"
+
+// IssueState is a linked issue as of the last sync.
+type IssueState struct {
+	Title  string `json:"title"`
+	Done   bool   `json:"done"`   // the TODO.md checkbox
+	Closed bool   `json:"closed"` // the issue
+}
+
+// State remembers every issue sync has linked, so that a deleted TODO.md
+// line isn't re-imported and a change on one side can be told apart from a
+// change on the other.
+type State struct {
+	Issues map[int]IssueState `json:"issues"`
+}
+
+// LoadState reads the sync state from path; a missing file is empty state.
+func LoadState(path string) (State, error) {
+	st := State{Issues: map[int]IssueState{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, err
+	}
+	if st.Issues == nil {
+		st.Issues = map[int]IssueState{}
+	}
+	return st, nil
+}
+
+// Save writes the state to path.
+func (st State) Save(path string) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ActionKind is one step of a sync.
+type ActionKind string
+
+const (
+	Import ActionKind = "import" // add an open issue to TODO.md
+	Create ActionKind = "create" // open an issue for an unlinked item
+	Close  ActionKind = "close"  // close an issue whose item was ticked
+	Reopen ActionKind = "reopen" // reopen an issue whose item was unticked
+	Tick   ActionKind = "tick"   // tick an item whose issue was closed
+	Untick ActionKind = "untick" // untick an item whose issue was reopened
+)
+
+// Action is a planned sync step. Item is nil for Import.
+type Action struct {
+	Kind  ActionKind
+	Issue int
+	Title string
+	Item  *Item
+}
+
+// Plan works out what a sync has to do given TODO.md, the repo's open
+// issues (number → title) and the state from the last sync. Unlinked,
+// unticked items become Create actions only when push is set. Running Plan
+// again after the actions are applied and the state saved yields nothing.
+func Plan(f *File, open map[int]string, st State, push bool) []Action {
+	var actions []Action
+	linked := map[int]bool{}
+	for _, item := range f.Items {
+		if item.Issue == 0 {
+			if push && !item.Done {
+				actions = append(actions, Action{Kind: Create, Title: item.Text, Item: item})
+			}
+			continue
+		}
+		if linked[item.Issue] {
+			continue
+		}
+		linked[item.Issue] = true
+
+		_, isOpen := open[item.Issue]
+		prev, known := st.Issues[item.Issue]
+		// Both sides were done last time, so whichever is now open changed.
+		wasDone := known && prev.Done && prev.Closed
+		switch {
+		case item.Done && isOpen && wasDone:
+			actions = append(actions, Action{Kind: Untick, Issue: item.Issue, Title: item.Text, Item: item})
+		case item.Done && isOpen:
+			actions = append(actions, Action{Kind: Close, Issue: item.Issue, Title: item.Text, Item: item})
+		case !item.Done && !isOpen && wasDone:
+			actions = append(actions, Action{Kind: Reopen, Issue: item.Issue, Title: item.Text, Item: item})
+		case !item.Done && !isOpen:
+			actions = append(actions, Action{Kind: Tick, Issue: item.Issue, Title: item.Text, Item: item})
+		}
+	}
+
+	numbers := make([]int, 0, len(open))
+	for n := range open {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	for _, n := range numbers {
+		// An issue sync has seen before but that's no longer in TODO.md was
+		// removed on purpose.
+		if _, known := st.Issues[n]; !linked[n] && !known {
+			actions = append(actions, Action{Kind: Import, Issue: n, Title: open[n]})
+		}
+	}
+	return actions
+}
+
+// Record updates st with the linked items in f after a sync. closed reports
+// whether each issue is now closed on the forge.
+func (st State) Record(f *File, closed func(issue int) bool) {
+	for _, item := range f.Items {
+		if item.Issue == 0 {
+			continue
+		}
+		st.Issues[item.Issue] = IssueState{Title: item.Text, Done: item.Done, Closed: closed(item.Issue)}
+	}
+}
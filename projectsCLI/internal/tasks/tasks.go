@@ -0,0 +1,118 @@
+// Package tasks reads and edits the checkbox list in a project's
+// tasks/TODO.md and plans its sync with forge issues. Items linked to an
+// issue end in its number, e.g. "- [ ] Fix login (#12)".
+package tasks
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	checkboxRe = regexp.MustCompile(`^(\s*[-*+]\s+)\[([ xX])\]\s+(.*?)\s*$`)
+	issueTagRe = regexp.MustCompile(`^(.*?)\s*\(#(\d+)\)$`)
+)
+
+// Item is one checkbox line.
+type Item struct {
+	Done  bool
+	Text  string // without the issue tag
+	Issue int    // linked issue number, or 0
+
+	line   int
+	prefix string // indentation and bullet, e.g. "  - "
+}
+
+// File is a parsed TODO.md. Lines that aren't checkbox items are kept as-is.
+type File struct {
+	Items []*Item
+	lines []string
+}
+
+// Parse reads a TODO.md.
+func Parse(data []byte) *File {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	f := &File{lines: strings.Split(strings.TrimSuffix(text, "\n"), "\n")}
+	if text == "" {
+		f.lines = nil
+	}
+	for i, line := range f.lines {
+		m := checkboxRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		item := &Item{Done: m[2] != " ", Text: m[3], line: i, prefix: m[1]}
+		if t := issueTagRe.FindStringSubmatch(m[3]); t != nil {
+			item.Text = t[1]
+			item.Issue, _ = strconv.Atoi(t[2])
+		}
+		f.Items = append(f.Items, item)
+	}
+	return f
+}
+
+// Add appends an unchecked item to the end of the "Active" section, or to
+// the end of the file if there is none.
+func (f *File) Add(text string, issue int) *Item {
+	at := len(f.lines)
+	prefix := "- "
+	if active := f.section("active"); active >= 0 {
+		at = active + 1
+		for i := active + 1; i < len(f.lines) && !isHeading(f.lines[i]); i++ {
+			if m := checkboxRe.FindStringSubmatch(f.lines[i]); m != nil {
+				at, prefix = i+1, m[1]
+			} else if at == active+1 && strings.TrimSpace(f.lines[i]) == "" {
+				at = i + 1 // keep the blank line under the heading
+			}
+		}
+	}
+
+	item := &Item{Text: text, Issue: issue, line: at, prefix: prefix}
+	f.lines = append(f.lines[:at], append([]string{""}, f.lines[at:]...)...)
+	for _, other := range f.Items {
+		if other.line >= at {
+			other.line++
+		}
+	}
+	f.Items = append(f.Items, item)
+	return item
+}
+
+// Bytes renders the file with its items' current state.
+func (f *File) Bytes() []byte {
+	for _, item := range f.Items {
+		f.lines[item.line] = item.String()
+	}
+	if len(f.lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(f.lines, "\n") + "\n")
+}
+
+// String renders the item as a markdown checkbox line.
+func (item *Item) String() string {
+	box := "[ ]"
+	if item.Done {
+		box = "[x]"
+	}
+	s := item.prefix + box + " " + item.Text
+	if item.Issue > 0 {
+		s += " (#" + strconv.Itoa(item.Issue) + ")"
+	}
+	return s
+}
+
+// section returns the line index of the "## <name>" heading, or -1.
+func (f *File) section(name string) int {
+	for i, line := range f.lines {
+		if isHeading(line) && strings.EqualFold(strings.TrimSpace(strings.TrimLeft(line, "#")), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isHeading(line string) bool {
+	return strings.HasPrefix(line, "#")
+}
@@ -0,0 +1,134 @@
+package tasks
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const todo = `# Demo — Tasks
+
+## Active
+
+- [ ] Initial setup
+- [x] Fix login (#12)
+  * [ ] Nested step
+
+## Done
+
+_Nothing yet._
+`
+
+func TestParseRoundTrip(t *testing.T) {
+	f := Parse([]byte(todo))
+	if len(f.Items) != 3 {
+		t.Fatalf("items = %d, want 3", len(f.Items))
+	}
+	login := f.Items[1]
+	if !login.Done || login.Text != "Fix login" || login.Issue != 12 {
+		t.Errorf("item = %+v", login)
+	}
+	if got := string(f.Bytes()); got != todo {
+		t.Errorf("round trip changed the file:\n%s", got)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	f := Parse([]byte(todo))
+	f.Add("Crash on start", 7)
+	f.Items[0].Done = true
+
+	want := `# Demo — Tasks
+
+## Active
+
+- [x] Initial setup
+- [x] Fix login (#12)
+  * [ ] Nested step
+  * [ ] Crash on start (#7)
+
+## Done
+
+_Nothing yet._
+`
+	if got := string(f.Bytes()); got != want {
+		t.Errorf("after Add:\n%s", got)
+	}
+
+	empty := Parse([]byte("## Active\n\n## Done\n"))
+	empty.Add("First", 1)
+	if got := string(empty.Bytes()); got != "## Active\n\n- [ ] First (#1)\n## Done\n" {
+		t.Errorf("Add to empty section:\n%q", got)
+	}
+
+	none := Parse(nil)
+	none.Add("Only", 0)
+	if got := string(none.Bytes()); got != "- [ ] Only\n" {
+		t.Errorf("Add to empty file = %q", got)
+	}
+}
+
+func kinds(actions []Action) map[int]ActionKind {
+	m := map[int]ActionKind{}
+	for _, a := range actions {
+		m[a.Issue] = a.Kind
+	}
+	return m
+}
+
+func TestPlan(t *testing.T) {
+	f := Parse([]byte(`## Active
+- [ ] Still open (#1)
+- [x] Ticked here (#2)
+- [ ] Closed there (#3)
+- [x] Reopened there (#4)
+- [ ] Unticked here (#5)
+- [ ] Not filed yet
+- [x] Done and unfiled
+`))
+	st := State{Issues: map[int]IssueState{
+		4: {Done: true, Closed: true},
+		5: {Done: true, Closed: true},
+		9: {Title: "Removed from TODO.md"},
+	}}
+	open := map[int]string{1: "Still open", 2: "Ticked here", 4: "Reopened there", 8: "New bug", 9: "Removed from TODO.md"}
+
+	got := kinds(Plan(f, open, st, false))
+	want := map[int]ActionKind{2: Close, 3: Tick, 4: Untick, 5: Reopen, 8: Import}
+	if len(got) != len(want) {
+		t.Fatalf("plan = %v, want %v", got, want)
+	}
+	for n, k := range want {
+		if got[n] != k {
+			t.Errorf("issue %d: %q, want %q", n, got[n], k)
+		}
+	}
+
+	var creates []string
+	for _, a := range Plan(f, open, st, true) {
+		if a.Kind == Create {
+			creates = append(creates, a.Title)
+		}
+	}
+	if len(creates) != 1 || creates[0] != "Not filed yet" {
+		t.Errorf("creates = %q", creates)
+	}
+}
+
+func TestPlanIdempotent(t *testing.T) {
+	f := Parse([]byte("## Active\n- [x] Done (#1)\n- [ ] Open (#2)\n"))
+	open := map[int]string{2: "Open"}
+	st := State{Issues: map[int]IssueState{}}
+	st.Record(f, func(n int) bool { _, ok := open[n]; return !ok })
+
+	path := filepath.Join(t.TempDir(), StateFile)
+	if err := st.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actions := Plan(f, open, loaded, true); len(actions) != 0 {
+		t.Errorf("second sync planned %+v", actions)
+	}
+}
@@ -31,8 +31,10 @@ type SpinnerModel struct {
 	spinner   spinner.Model
 	message   string
 	messages  []string
+	progress  string
 	tickCount int
 	done      bool
+	cancelled bool
 	err       error
 }
 
@@ -41,6 +43,36 @@ type DoneMsg struct {
 	Err error
 }
 
+// ProgressMsg updates the progress text shown after the spinner message,
+// e.g. "12/150".
+type ProgressMsg struct {
+	Text string
+}
+
+// RunWithSpinner shows a spinner while work runs in the background. work
+// reports progress through the callback it is given. Pressing Ctrl-C calls
+// cancel; RunWithSpinner still waits for work to return and returns its error.
+func RunWithSpinner(message string, cancel func(), work func(progress func(string)) error) error {
+	p := tea.NewProgram(NewSpinnerModel(message))
+
+	done := make(chan error, 1)
+	go func() {
+		err := work(func(text string) { p.Send(ProgressMsg{Text: text}) })
+		done <- err
+		p.Send(DoneMsg{Err: err})
+	}()
+
+	finalModel, runErr := p.Run()
+	if m, ok := finalModel.(SpinnerModel); ok && m.cancelled {
+		cancel()
+	}
+	err := <-done
+	if err == nil && runErr != nil {
+		return runErr
+	}
+	return err
+}
+
 // NewSpinnerModel creates a themed spinner for long-running work.
 func NewSpinnerModel(message string) SpinnerModel {
 	s := spinner.New()
@@ -68,6 +100,9 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.done = true
 		m.err = typed.Err
 		return m, tea.Quit
+	case ProgressMsg:
+		m.progress = typed.Text
+		return m, nil
 	case spinner.TickMsg:
 		m.tickCount++
 		if m.tickCount > 0 && m.tickCount%20 == 0 && len(m.messages) > 0 {
@@ -80,6 +115,7 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		if typed.String() == "ctrl+c" {
 			m.done = true
+			m.cancelled = true
 			m.err = fmt.Errorf("cancelled")
 			return m, tea.Quit
 		}
@@ -94,6 +130,9 @@ func (m SpinnerModel) View() string {
 		}
 		return SuccessMessage("Done! " + RandomCelebration())
 	}
+	if m.progress != "" {
+		return fmt.Sprintf("%s %s %s", m.spinner.View(), m.message, Muted(m.progress))
+	}
 	return fmt.Sprintf("%s %s", m.spinner.View(), m.message)
 }
 
@@ -85,6 +85,9 @@ func (m DetailModel) renderContent() string {
 	if p.Meta.Description != "" {
 		sb.WriteString(FormatField("Description", p.Meta.Description) + "\n")
 	}
+	if p.Meta.Homepage != "" {
+		sb.WriteString(FormatField("Homepage", Path(p.Meta.Homepage)) + "\n")
+	}
 	if len(p.Meta.Tags) > 0 {
 		sb.WriteString(FormatField("Tags", TagList(p.Meta.Tags)) + "\n")
 	}
@@ -208,6 +208,16 @@ var folderRemoveQuips = []string{
 // RandomFolderRemoveQuip returns a random folder removal quip.
 func RandomFolderRemoveQuip() string { return pick(folderRemoveQuips) }
 
+var vaultLockCheers = []string{
+	"Secrets tucked in for the night.",
+	"Locked tight. Nobody's peeking.",
+	"Vault sealed. Laptop thieves in shambles.",
+	"Encrypted and unbothered.",
+}
+
+// RandomVaultLockCheer returns a random vault lock celebration.
+func RandomVaultLockCheer() string { return pick(vaultLockCheers) }
+
 var statusHeaders = []string{
 	"🩺 Project Health Check",
 	"📊 Project Vitals",
@@ -0,0 +1,401 @@
+// Package vault encrypts a project's private/ directory into a single
+// private.vault file using a passphrase-derived key.
+//
+// File layout: magic (8 bytes) | salt (16) | PBKDF2 iterations (uint32, big
+// endian) | nonce (12) | AES-256-GCM ciphertext of a gzipped tar of private/.
+// The header is authenticated as additional data, so tampering with any byte
+// fails decryption.
+package vault
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// FileName is the vault file written next to private/.
+	FileName = "private.vault"
+
+	magic     = "PJVAULT\x01"
+	saltSize  = 16
+	nonceSize = 12
+	keySize   = 32
+)
+
+// Iterations is the PBKDF2-SHA256 work factor for newly locked vaults.
+// Existing vaults record their own count in the header.
+var Iterations = 600_000
+
+// maxIterations caps the count read from a vault header, so a corrupt or
+// tampered file can't stall unlocking for hours.
+const maxIterations = 10 * 600_000
+
+// ErrBadPassphrase is returned when a vault can't be decrypted, either
+// because the passphrase is wrong or the file was modified.
+var ErrBadPassphrase = errors.New("wrong passphrase or corrupted vault")
+
+// Status describes the vault state of a project.
+type Status struct {
+	Locked     bool      `json:"locked"`
+	Plaintext  bool      `json:"plaintext"`
+	VaultPath  string    `json:"vault_path"`
+	VaultSize  int64     `json:"vault_size,omitempty"`
+	LockedAt   time.Time `json:"locked_at,omitzero"`
+	PrivateDir string    `json:"private_dir"`
+}
+
+// VaultPath returns the vault file path for a project directory.
+func VaultPath(projectDir string) string {
+	return filepath.Join(projectDir, FileName)
+}
+
+// PrivateDir returns the private/ path for a project directory.
+func PrivateDir(projectDir string) string {
+	return filepath.Join(projectDir, "private")
+}
+
+// IsLocked reports whether a project has a vault file.
+func IsLocked(projectDir string) bool {
+	_, err := os.Stat(VaultPath(projectDir))
+	return err == nil
+}
+
+// GetStatus reports whether a project is locked and whether plaintext
+// private files are present.
+func GetStatus(projectDir string) Status {
+	st := Status{
+		VaultPath:  VaultPath(projectDir),
+		PrivateDir: PrivateDir(projectDir),
+	}
+	if info, err := os.Stat(st.VaultPath); err == nil {
+		st.Locked = true
+		st.VaultSize = info.Size()
+		st.LockedAt = info.ModTime()
+	}
+	if entries, err := os.ReadDir(st.PrivateDir); err == nil && len(entries) > 0 {
+		st.Plaintext = true
+	}
+	return st
+}
+
+// Lock archives and encrypts private/ into private.vault, then removes the
+// plaintext directory. The vault is written and verified before anything is
+// deleted.
+func Lock(projectDir, passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("passphrase cannot be empty")
+	}
+	if IsLocked(projectDir) {
+		return fmt.Errorf("already locked: %s exists", FileName)
+	}
+
+	privDir := PrivateDir(projectDir)
+	if _, err := os.Stat(privDir); err != nil {
+		return fmt.Errorf("nothing to lock: %w", err)
+	}
+
+	archive, err := archiveDir(privDir)
+	if err != nil {
+		return fmt.Errorf("archive private/: %w", err)
+	}
+
+	data, err := seal(archive, passphrase, Iterations)
+	if err != nil {
+		return err
+	}
+
+	vaultPath := VaultPath(projectDir)
+	if err := writeFileAtomic(vaultPath, data, 0600); err != nil {
+		return fmt.Errorf("write vault: %w", err)
+	}
+
+	// Make sure what's on disk decrypts before destroying the plaintext.
+	written, err := os.ReadFile(vaultPath)
+	if err == nil {
+		_, err = open(written, passphrase)
+	}
+	if err != nil {
+		os.Remove(vaultPath)
+		return fmt.Errorf("verify vault: %w", err)
+	}
+
+	if err := os.RemoveAll(privDir); err != nil {
+		return fmt.Errorf("remove plaintext private/: %w", err)
+	}
+	return nil
+}
+
+// Unlock decrypts private.vault back into private/ and removes the vault.
+// It refuses to overwrite a non-empty private/ directory.
+func Unlock(projectDir, passphrase string) error {
+	archive, err := decryptFile(projectDir, passphrase)
+	if err != nil {
+		return err
+	}
+
+	privDir := PrivateDir(projectDir)
+	if entries, err := os.ReadDir(privDir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("private/ is not empty; move its contents aside before unlocking")
+	}
+	if err := os.MkdirAll(privDir, 0700); err != nil {
+		return err
+	}
+
+	if err := extractArchive(archive, privDir); err != nil {
+		return fmt.Errorf("extract vault: %w", err)
+	}
+	return os.Remove(VaultPath(projectDir))
+}
+
+// ReadFile decrypts the vault in memory and returns the contents of name
+// (relative to private/). Nothing is written to disk.
+func ReadFile(projectDir, passphrase, name string) ([]byte, error) {
+	archive, err := decryptFile(projectDir, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	want := path.Clean(filepath.ToSlash(name))
+	tr, err := tarReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Clean(hdr.Name) == want {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+func decryptFile(projectDir, passphrase string) ([]byte, error) {
+	data, err := os.ReadFile(VaultPath(projectDir))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("not locked: no %s", FileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return open(data, passphrase)
+}
+
+// seal encrypts plaintext with a key derived from passphrase.
+func seal(plaintext []byte, passphrase string, iterations int) ([]byte, error) {
+	header := make([]byte, 0, len(magic)+saltSize+4+nonceSize)
+	header = append(header, magic...)
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, uint32(iterations))
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	header = append(header, nonce...)
+
+	gcm, err := newGCM(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Seal(header, nonce, plaintext, header), nil
+}
+
+// open decrypts data produced by seal.
+func open(data []byte, passphrase string) ([]byte, error) {
+	headerSize := len(magic) + saltSize + 4 + nonceSize
+	if len(data) < headerSize || string(data[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not a projects vault file")
+	}
+
+	header := data[:headerSize]
+	salt := header[len(magic) : len(magic)+saltSize]
+	iterations := int(binary.BigEndian.Uint32(header[len(magic)+saltSize:]))
+	nonce := header[headerSize-nonceSize:]
+
+	gcm, err := newGCM(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations < 1 || iterations > maxIterations {
+		return nil, fmt.Errorf("invalid vault header")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// archiveDir writes a gzipped tar of dir's regular files and directories.
+func archiveDir(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return fmt.Errorf("%s: only regular files and directories can be locked", rel)
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func tarReader(archive []byte) (*tar.Reader, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	return tar.NewReader(gz), nil
+}
+
+// extractArchive unpacks archive into dir, rejecting entries that would land
+// outside it.
+func extractArchive(archive []byte, dir string) error {
+	tr, err := tarReader(archive)
+	if err != nil {
+		return err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !filepath.IsLocal(filepath.FromSlash(hdr.Name)) {
+			return fmt.Errorf("refusing unsafe path %q", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, hdr.FileInfo().Mode().Perm()|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_EXCL, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported entry %q", hdr.Name)
+		}
+	}
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and
+// renames it into place.
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,93 @@
+package vault
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	// Keep tests fast; real vaults use the default work factor.
+	Iterations = 1000
+}
+
+func TestLockUnlockRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	priv := PrivateDir(dir)
+	if err := os.MkdirAll(filepath.Join(priv, "keys"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(priv, ".env"), []byte("API_KEY=secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(priv, "keys", "id"), []byte("key material"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Lock(dir, "hunter2"); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if _, err := os.Stat(priv); !os.IsNotExist(err) {
+		t.Fatalf("expected private/ to be removed after lock, stat err = %v", err)
+	}
+	if st := GetStatus(dir); !st.Locked || st.Plaintext {
+		t.Errorf("unexpected status after lock: %+v", st)
+	}
+	if err := Lock(dir, "hunter2"); err == nil {
+		t.Error("expected error locking twice")
+	}
+
+	env, err := ReadFile(dir, "hunter2", ".env")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(env) != "API_KEY=secret\n" {
+		t.Errorf("ReadFile = %q", env)
+	}
+	if _, err := ReadFile(dir, "hunter2", "missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadFile(missing) err = %v, want ErrNotExist", err)
+	}
+	if _, err := os.Stat(priv); !os.IsNotExist(err) {
+		t.Error("ReadFile must not write plaintext to disk")
+	}
+
+	if err := Unlock(dir, "wrong"); !errors.Is(err, ErrBadPassphrase) {
+		t.Fatalf("Unlock with wrong passphrase err = %v, want ErrBadPassphrase", err)
+	}
+	if err := Unlock(dir, "hunter2"); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(priv, "keys", "id"))
+	if err != nil || string(got) != "key material" {
+		t.Errorf("restored file = %q, %v", got, err)
+	}
+	if IsLocked(dir) {
+		t.Error("vault file should be removed after unlock")
+	}
+}
+
+func TestOpenRejectsTampering(t *testing.T) {
+	data, err := seal([]byte("payload"), "pass", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{len(magic) + 1, len(data) - 1} {
+		tampered := append([]byte(nil), data...)
+		tampered[i] ^= 0xff
+		if _, err := open(tampered, "pass"); !errors.Is(err, ErrBadPassphrase) {
+			t.Errorf("tampered byte %d: err = %v, want ErrBadPassphrase", i, err)
+		}
+	}
+	if _, err := open([]byte("garbage"), "pass"); err == nil {
+		t.Error("expected error for non-vault data")
+	}
+	// An absurd work factor is rejected before deriving the key.
+	tampered := append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(tampered[len(magic)+saltSize:], math.MaxUint32)
+	if _, err := open(tampered, "pass"); err == nil || errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("huge iteration count: err = %v, want an invalid header", err)
+	}
+}